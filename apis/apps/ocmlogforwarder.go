@@ -18,6 +18,7 @@ package apps
 
 import (
 	v1alpha1apps "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	v1beta1apps "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1"
 	//+kubebuilder:scaffold:operator-builder:imports
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -27,6 +28,7 @@ import (
 func OCMLogForwarderGroupVersions() []schema.GroupVersion {
 	return []schema.GroupVersion{
 		v1alpha1apps.GroupVersion,
+		v1beta1apps.GroupVersion,
 		//+kubebuilder:scaffold:operator-builder:groupversions
 	}
 }
@@ -17,16 +17,16 @@ limitations under the License.
 package apps
 
 import (
-	v1alpha1apps "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
-	v1alpha1ocmlogforwarder "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+	v1beta1apps "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1"
+	v1beta1ocmlogforwarder "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1/ocmlogforwarder"
 )
 
 // Code generated by operator-builder. DO NOT EDIT.
 
 // OCMLogForwarderLatestGroupVersion returns the latest group version object associated with this
 // particular kind.
-var OCMLogForwarderLatestGroupVersion = v1alpha1apps.GroupVersion
+var OCMLogForwarderLatestGroupVersion = v1beta1apps.GroupVersion
 
 // OCMLogForwarderLatestSample returns the latest sample manifest associated with this
 // particular kind.
-var OCMLogForwarderLatestSample = v1alpha1ocmlogforwarder.Sample(false)
+var OCMLogForwarderLatestSample = v1beta1ocmlogforwarder.Sample(false)
@@ -0,0 +1,200 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterocmlogforwarder
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+)
+
+// sampleClusterOCMLogForwarder is a sample containing all fields
+const sampleClusterOCMLogForwarder = `apiVersion: apps.dustinscott.io/v1alpha1
+kind: ClusterOCMLogForwarder
+metadata:
+  name: clusterocmlogforwarder-sample
+spec:
+  targetNamespace: "ocm-log-forwarder"
+  ocm:
+    secretRef: "ocm-token"
+    authType: "offlineToken"
+    clusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"
+    pollInternalMinutes: 5
+    apiURL: "https://api.openshift.com"
+    tokenURL: "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+  backend:
+    elasticSearch:
+      secretRef: "elastic-auth"
+      url: "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200"
+      authType: "basic"
+      index: "ocm_service_logs"
+      indexPattern: ""
+      ilm:
+        policyName: ""
+        rolloverAlias: ""
+      dataStream:
+        enabled: false
+        namespace: "default"
+      manageIndexTemplate: false
+      retention: ""
+      tls:
+        caRef:
+          kind: "Secret"
+          name: ""
+          key: "ca.crt"
+        clientCertSecretRef: ""
+        insecureSkipVerify: false
+      elasticsearchRef:
+        name: ""
+        namespace: ""
+    splunk:
+      secretRef: "splunk-hec-token"
+      url: "https://splunk.example.com:8088"
+      index: "ocm_service_logs"
+      sourceType: "ocm:servicelog"
+      source: "ocm-log-forwarder"
+      insecureSkipVerify: false
+    loki:
+      secretRef: "loki-auth"
+      url: "http://loki-gateway.loki.svc.cluster.local/loki/api/v1/push"
+      tenantId: "ocm"
+      labels:
+        source: "ocm"
+      authType: "none"
+    kafka:
+      secretRef: "kafka-auth"
+      bootstrapServers:
+        - "kafka-kafka-bootstrap.kafka.svc.cluster.local:9093"
+      topic: "ocm-service-logs"
+      keyStrategy: "clusterId"
+      authType: "scram-sha-512"
+    s3:
+      secretRef: "s3-credentials"
+      endpoint: "https://minio.minio.svc.cluster.local:9000"
+      bucket: "ocm-service-logs"
+      prefix: "ocm-service-logs/{clusterId}/{year}/{month}/{day}"
+      region: "us-east-1"
+      forcePathStyle: true
+      format: "jsonl-gzip"
+      flushIntervalMinutes: 60
+    type: "elasticsearch"
+  proxy:
+    httpProxy: ""
+    httpsProxy: ""
+    noProxy: ""
+    inheritClusterProxy: false
+    injectTrustedCABundle: false
+  filter:
+    minSeverity: "Info"
+    includeServiceNames: []
+    excludeServiceNames: []
+    summaryRegex: ""
+    maxAgeMinutes: 1440
+  version: "latest"
+  image: "ghcr.io/scottd018/ocm-log-forwarder"
+  debug: false
+  suspend: false
+`
+
+// sampleClusterOCMLogForwarderRequired is a sample containing only required fields
+const sampleClusterOCMLogForwarderRequired = `apiVersion: apps.dustinscott.io/v1alpha1
+kind: ClusterOCMLogForwarder
+metadata:
+  name: clusterocmlogforwarder-sample
+spec:
+  ocm:
+    clusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"
+`
+
+// Sample returns the sample manifest for this custom resource.
+func Sample(requiredOnly bool) string {
+	if requiredOnly {
+		return sampleClusterOCMLogForwarderRequired
+	}
+
+	return sampleClusterOCMLogForwarder
+}
+
+// Generate returns the child resources that are associated with this workload given
+// appropriate structured inputs.  The child resources are those of the OCMLogForwarder
+// which the workload deploys to its target namespace.
+func Generate(
+	workloadObj appsv1alpha1.ClusterOCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	return ocmlogforwarder.Generate(*workloadObj.ToOCMLogForwarder(), reconciler, req)
+}
+
+// GenerateForCLI returns the child resources that are associated with this workload given
+// appropriate YAML manifest files.
+func GenerateForCLI(workloadFile []byte) ([]client.Object, error) {
+	var workloadObj appsv1alpha1.ClusterOCMLogForwarder
+	if err := yaml.Unmarshal(workloadFile, &workloadObj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+	}
+
+	if err := workload.Validate(&workloadObj); err != nil {
+		return nil, fmt.Errorf("error validating workload yaml, %w", err)
+	}
+
+	// the defaults of the custom resource definition are not applied to a manifest which is read from a file.
+	workloadObj.Spec.SetDefaults()
+
+	return Generate(workloadObj, nil, nil)
+}
+
+// Phase adapts a phase of an OCMLogForwarder so that it may be run for a ClusterOCMLogForwarder workload.
+// The phase is run for the OCMLogForwarder which the workload deploys to its target namespace, and the
+// status which is set by the phase is then set on the workload.
+func Phase(
+	phase func(workload.Reconciler, *workload.Request) (bool, error),
+) func(workload.Reconciler, *workload.Request) (bool, error) {
+	return func(r workload.Reconciler, req *workload.Request) (bool, error) {
+		component, err := ConvertWorkload(req.Workload)
+		if err != nil {
+			return false, err
+		}
+
+		forwarder := component.ToOCMLogForwarder()
+
+		proceed, err := phase(r, &workload.Request{
+			Context:  req.Context,
+			Workload: forwarder,
+			Log:      req.Log,
+		})
+
+		component.SetOCMLogForwarderStatus(forwarder)
+
+		return proceed, err
+	}
+}
+
+func ConvertWorkload(component workload.Workload) (*appsv1alpha1.ClusterOCMLogForwarder, error) {
+	p, ok := component.(*appsv1alpha1.ClusterOCMLogForwarder)
+	if !ok {
+		return nil, appsv1alpha1.ErrUnableToConvertClusterOCMLogForwarder
+	}
+
+	return p, nil
+}
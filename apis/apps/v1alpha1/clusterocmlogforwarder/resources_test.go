@@ -0,0 +1,145 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterocmlogforwarder
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+func TestGenerateForCLI(t *testing.T) {
+	for _, requiredOnly := range []bool{false, true} {
+		resources, err := GenerateForCLI([]byte(Sample(requiredOnly)))
+		if err != nil {
+			t.Errorf("required only [%t]: unexpected error: %v", requiredOnly, err)
+
+			continue
+		}
+
+		found := false
+
+		for _, resource := range resources {
+			found = found || resource.GetObjectKind().GroupVersionKind().Kind == "Deployment"
+		}
+
+		if !found {
+			t.Errorf("required only [%t]: expected a deployment to be generated", requiredOnly)
+		}
+	}
+}
+
+func TestGenerateForCLIDefaults(t *testing.T) {
+	resources, err := GenerateForCLI([]byte(Sample(true)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, resource := range resources {
+		object, ok := resource.(*unstructured.Unstructured)
+		if !ok {
+			t.Fatalf("expected an unstructured object; found %T", resource)
+		}
+
+		switch object.GetKind() {
+		case "Deployment":
+			// the values are not deep copied, as the generated objects contain values such as int.
+			containers, _, _ := unstructured.NestedFieldNoCopy(object.Object, "spec", "template", "spec", "containers")
+			env := map[string]string{}
+
+			for _, variable := range containers.([]interface{})[0].(map[string]interface{})["env"].([]interface{}) {
+				variable := variable.(map[string]interface{})
+				env[variable["name"].(string)] = fmt.Sprint(variable["value"])
+			}
+
+			for name, want := range map[string]string{
+				"OCM_SECRET_NAME":           "ocm-token",
+				"OCM_POLL_INTERVAL_MINUTES": "5",
+				"OCM_API_URL":               "https://api.openshift.com",
+			} {
+				if env[name] != want {
+					t.Errorf("expected %s [%s]; found [%s]", name, want, env[name])
+				}
+			}
+		case "Role":
+			rules, _, _ := unstructured.NestedFieldNoCopy(object.Object, "rules")
+
+			for _, rule := range rules.([]interface{}) {
+				if names, ok := rule.(map[string]interface{})["resourceNames"]; ok && len(names.([]interface{})) == 0 {
+					t.Errorf("expected role [%s] to be restricted to named resources; found %v", object.GetName(), rule)
+				}
+			}
+		}
+	}
+}
+
+func TestPhase(t *testing.T) {
+	errPhase := errors.New("phase failed")
+
+	for _, tt := range []struct {
+		name    string
+		proceed bool
+		err     error
+	}{
+		{name: "proceed", proceed: true},
+		{name: "requeue", proceed: false},
+		{name: "error", err: errPhase},
+	} {
+		component := &appsv1alpha1.ClusterOCMLogForwarder{
+			ObjectMeta: metav1.ObjectMeta{Name: "forwarder", UID: "0a9c3d6e-8f1b-4c27-9e5d-3b2a7f6c1d48"},
+			Spec:       appsv1alpha1.ClusterOCMLogForwarderSpec{TargetNamespace: "logging"},
+		}
+
+		phase := Phase(func(r workload.Reconciler, req *workload.Request) (bool, error) {
+			forwarder, ok := req.Workload.(*appsv1alpha1.OCMLogForwarder)
+			if !ok {
+				t.Fatalf("%s: expected phase to be run for an OCMLogForwarder; found %T", tt.name, req.Workload)
+			}
+
+			if forwarder.Name != "cluster-forwarder" || forwarder.Namespace != "logging" || forwarder.UID != component.UID {
+				t.Errorf("%s: expected phase to be run for the OCMLogForwarder of the component; found [%s/%s] [%s]",
+					tt.name, forwarder.Namespace, forwarder.Name, forwarder.UID)
+			}
+
+			forwarder.SetResolvedImageStatus("ghcr.io/scottd018/ocm-log-forwarder:latest@sha256:0")
+
+			return tt.proceed, tt.err
+		})
+
+		proceed, err := phase(nil, &workload.Request{Workload: component, Log: logr.Discard()})
+		if proceed != tt.proceed || !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected proceed [%t] with error [%v]; found [%t] with error [%v]", tt.name, tt.proceed, tt.err, proceed, err)
+		}
+
+		// the status which is set by the phase is set on the component, whether or not the phase succeeded.
+		if component.Status.ResolvedImage != "ghcr.io/scottd018/ocm-log-forwarder:latest@sha256:0" {
+			t.Errorf("%s: expected status set by the phase to be set on the component; found %+v", tt.name, component.Status)
+		}
+	}
+
+	_, err := Phase(nil)(nil, &workload.Request{Workload: &appsv1alpha1.OCMLogForwarder{}})
+	if !errors.Is(err, appsv1alpha1.ErrUnableToConvertClusterOCMLogForwarder) {
+		t.Errorf("expected error [%v]; found [%v]", appsv1alpha1.ErrUnableToConvertClusterOCMLogForwarder, err)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ErrUnableToConvertClusterOCMLogForwarder = errors.New("unable to convert to ClusterOCMLogForwarder")
+
+// DefaultTargetNamespace is the namespace in which the forwarder of a ClusterOCMLogForwarder is deployed when
+// no target namespace is set.
+const DefaultTargetNamespace = "ocm-log-forwarder"
+
+// ClusterOCMLogForwarderPrefix is the prefix of the name of the OCMLogForwarder which a ClusterOCMLogForwarder
+// deploys, so that its child resources do not collide with those of an OCMLogForwarder of the same name in the
+// target namespace.
+const ClusterOCMLogForwarderPrefix = "cluster-"
+
+// ClusterOCMLogForwarderSpec defines the desired state of ClusterOCMLogForwarder.
+type ClusterOCMLogForwarderSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// +kubebuilder:default="ocm-log-forwarder"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// (Default: "ocm-log-forwarder")
+	//  Namespace in which the forwarder is deployed.  The namespace must exist, and the secrets which are
+	//  referenced by the forwarder are read from this namespace.
+	//
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// The forwarder configuration, which is the same as the spec of an OCMLogForwarder.
+	OCMLogForwarderSpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Target Namespace",type=string,JSONPath=`.spec.targetNamespace`
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.status.suspended`
+// +kubebuilder:printcolumn:name="Created",type=boolean,JSONPath=`.status.created`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterOCMLogForwarder is the Schema for the clusterocmlogforwarders API.  It is a cluster-scoped
+// OCMLogForwarder, which deploys the forwarder to a target namespace.
+type ClusterOCMLogForwarder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ClusterOCMLogForwarderSpec `json:"spec,omitempty"`
+	Status            OCMLogForwarderStatus      `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterOCMLogForwarderList contains a list of ClusterOCMLogForwarder.
+type ClusterOCMLogForwarderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterOCMLogForwarder `json:"items"`
+}
+
+// interface methods
+
+// GetReadyStatus returns the ready status for a component.
+func (component *ClusterOCMLogForwarder) GetReadyStatus() bool {
+	return component.Status.Created
+}
+
+// SetReadyStatus sets the ready status for a component.
+func (component *ClusterOCMLogForwarder) SetReadyStatus(ready bool) {
+	component.Status.Created = ready
+}
+
+// GetDependencyStatus returns the dependency status for a component.
+func (component *ClusterOCMLogForwarder) GetDependencyStatus() bool {
+	return component.Status.DependenciesSatisfied
+}
+
+// SetDependencyStatus sets the dependency status for a component.
+func (component *ClusterOCMLogForwarder) SetDependencyStatus(dependencyStatus bool) {
+	component.Status.DependenciesSatisfied = dependencyStatus
+}
+
+// GetPhaseConditions returns the phase conditions for a component.
+func (component *ClusterOCMLogForwarder) GetPhaseConditions() []*status.PhaseCondition {
+	return (&OCMLogForwarder{Status: component.Status}).GetPhaseConditions()
+}
+
+// SetPhaseCondition sets the phase conditions for a component.
+func (component *ClusterOCMLogForwarder) SetPhaseCondition(condition *status.PhaseCondition) {
+	component.withOCMLogForwarderStatus(func(forwarder *OCMLogForwarder) {
+		forwarder.SetPhaseCondition(condition)
+	})
+}
+
+// GetResources returns the child resource status for a component.
+func (component *ClusterOCMLogForwarder) GetChildResourceConditions() []*status.ChildResource {
+	return component.Status.Resources
+}
+
+// SetResources sets the phase conditions for a component.
+func (component *ClusterOCMLogForwarder) SetChildResourceCondition(resource *status.ChildResource) {
+	component.withOCMLogForwarderStatus(func(forwarder *OCMLogForwarder) {
+		forwarder.SetChildResourceCondition(resource)
+	})
+}
+
+// GetDependencies returns the dependencies for a component, which are the ECK managed Elasticsearch objects
+// that are referenced by the elasticsearch backends.
+func (component *ClusterOCMLogForwarder) GetDependencies() []workload.Workload {
+	return component.ToOCMLogForwarder().GetDependencies()
+}
+
+// GetComponentGVK returns a GVK object for the component.
+func (*ClusterOCMLogForwarder) GetWorkloadGVK() schema.GroupVersionKind {
+	return GroupVersion.WithKind("ClusterOCMLogForwarder")
+}
+
+// GetTargetNamespace returns the namespace in which the forwarder of a component is deployed.
+func (component *ClusterOCMLogForwarder) GetTargetNamespace() string {
+	if component.Spec.TargetNamespace == "" {
+		return DefaultTargetNamespace
+	}
+
+	return component.Spec.TargetNamespace
+}
+
+// ToOCMLogForwarder returns the OCMLogForwarder which a component deploys to its target namespace, so that
+// the logic of an OCMLogForwarder, such as the generation of its child resources, may be reused.  The returned
+// OCMLogForwarder is named after the component with the ClusterOCMLogForwarderPrefix.  The status
+// of the returned OCMLogForwarder is a copy, which must be set on the component with SetOCMLogForwarderStatus
+// when it is modified.  The returned OCMLogForwarder has the UID of the component, which owns the child
+// resources, so that ownership of the child resources may be checked.
+func (component *ClusterOCMLogForwarder) ToOCMLogForwarder() *OCMLogForwarder {
+	forwarder := &OCMLogForwarder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ClusterOCMLogForwarderPrefix + component.Name,
+			Namespace:   component.GetTargetNamespace(),
+			UID:         component.UID,
+			Labels:      component.Labels,
+			Annotations: component.Annotations,
+		},
+		Spec:   *component.Spec.OCMLogForwarderSpec.DeepCopy(),
+		Status: *component.Status.DeepCopy(),
+	}
+
+	forwarder.SetGroupVersionKind(forwarder.GetWorkloadGVK())
+
+	return forwarder
+}
+
+// SetOCMLogForwarderStatus sets the status of a component from the status of the OCMLogForwarder which was
+// returned by ToOCMLogForwarder.
+func (component *ClusterOCMLogForwarder) SetOCMLogForwarderStatus(forwarder *OCMLogForwarder) {
+	component.Status = forwarder.Status
+}
+
+// withOCMLogForwarderStatus runs a function which modifies the status of the OCMLogForwarder which a component
+// deploys, and sets the modified status on the component.
+func (component *ClusterOCMLogForwarder) withOCMLogForwarderStatus(modify func(*OCMLogForwarder)) {
+	forwarder := &OCMLogForwarder{Status: component.Status}
+
+	modify(forwarder)
+
+	component.Status = forwarder.Status
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterOCMLogForwarder{}, &ClusterOCMLogForwarderList{})
+}
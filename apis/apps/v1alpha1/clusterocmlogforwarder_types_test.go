@@ -0,0 +1,112 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToOCMLogForwarder(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		targetNamespace string
+		namespace       string
+	}{
+		{name: "default target namespace", namespace: DefaultTargetNamespace},
+		{name: "target namespace", targetNamespace: "logging", namespace: "logging"},
+	} {
+		component := &ClusterOCMLogForwarder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "forwarder",
+				UID:         "0a9c3d6e-8f1b-4c27-9e5d-3b2a7f6c1d48",
+				Labels:      map[string]string{"team": "platform"},
+				Annotations: map[string]string{ResolveImageAnnotation: "2023-06-01T00:00:00Z"},
+			},
+			Spec: ClusterOCMLogForwarderSpec{
+				TargetNamespace: tt.targetNamespace,
+				OCMLogForwarderSpec: OCMLogForwarderSpec{
+					Ocm:     OCMLogForwarderSpecOcm{ClusterId: "abc123"},
+					Backend: OCMLogForwarderSpecBackend{Type: BackendTypeElasticSearch},
+				},
+			},
+			Status: OCMLogForwarderStatus{ResolvedImage: "ghcr.io/scottd018/ocm-log-forwarder:latest@sha256:0"},
+		}
+
+		forwarder := component.ToOCMLogForwarder()
+
+		if forwarder.Name != "cluster-forwarder" || forwarder.Namespace != tt.namespace {
+			t.Errorf("%s: expected [%s/cluster-forwarder]; found [%s/%s]", tt.name, tt.namespace, forwarder.Namespace, forwarder.Name)
+		}
+
+		if forwarder.UID != component.UID {
+			t.Errorf("%s: expected uid [%s]; found [%s]", tt.name, component.UID, forwarder.UID)
+		}
+
+		if forwarder.Labels["team"] != "platform" || forwarder.GetResolveImageRequest() != "2023-06-01T00:00:00Z" {
+			t.Errorf("%s: expected labels and annotations of the component; found %v %v", tt.name, forwarder.Labels, forwarder.Annotations)
+		}
+
+		if gvk := forwarder.GetObjectKind().GroupVersionKind(); gvk != forwarder.GetWorkloadGVK() {
+			t.Errorf("%s: expected kind [%s]; found [%s]", tt.name, forwarder.GetWorkloadGVK(), gvk)
+		}
+
+		if forwarder.Spec.Ocm.ClusterId != "abc123" || forwarder.GetResolvedImageStatus() != component.Status.ResolvedImage {
+			t.Errorf("%s: expected spec and status of the component; found %+v %+v", tt.name, forwarder.Spec.Ocm, forwarder.Status)
+		}
+
+		// the spec and status are copies, which do not modify the component until the status is set.
+		forwarder.Spec.Ocm.ClusterId = "def456"
+		forwarder.SetResolvedImageStatus("")
+
+		if component.Spec.Ocm.ClusterId != "abc123" || component.Status.ResolvedImage == "" {
+			t.Errorf("%s: expected the component to be unmodified; found %+v %+v", tt.name, component.Spec.Ocm, component.Status)
+		}
+	}
+}
+
+func TestSetOCMLogForwarderStatus(t *testing.T) {
+	component := &ClusterOCMLogForwarder{ObjectMeta: metav1.ObjectMeta{Name: "forwarder"}}
+
+	forwarder := component.ToOCMLogForwarder()
+	forwarder.SetSuspendedStatus(true)
+	forwarder.SetResolvedImageStatus("ghcr.io/scottd018/ocm-log-forwarder:latest@sha256:0")
+	forwarder.SetPhaseCondition(&status.PhaseCondition{Phase: "Validate-Secrets", State: status.PhaseStateComplete})
+
+	component.SetOCMLogForwarderStatus(forwarder)
+
+	if !component.Status.Suspended || component.Status.ResolvedImage != forwarder.Status.ResolvedImage {
+		t.Errorf("expected the status of the forwarder; found %+v", component.Status)
+	}
+
+	// conditions set on the component are set with the condition types of an OCMLogForwarder.
+	component.SetPhaseCondition(&status.PhaseCondition{Phase: "Validate-Secrets", State: status.PhaseStateFailed})
+
+	for _, condition := range component.Status.Conditions {
+		if condition.Phase == "Validate-Secrets" &&
+			(condition.Type != ConditionTypeSecretsValid || condition.Status != metav1.ConditionFalse) {
+			t.Errorf("expected condition [%s=%s]; found [%s=%s]",
+				ConditionTypeSecretsValid, metav1.ConditionFalse, condition.Type, condition.Status)
+		}
+	}
+
+	if len(component.GetPhaseConditions()) != len(forwarder.Status.Conditions) {
+		t.Errorf("expected a single condition for each phase; found %d", len(component.GetPhaseConditions()))
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ElasticsearchGroupVersionKind is the group, version and kind of an ECK managed Elasticsearch.
+var ElasticsearchGroupVersionKind = schema.GroupVersionKind{
+	Group:   "elasticsearch.k8s.elastic.co",
+	Version: "v1",
+	Kind:    "Elasticsearch",
+}
+
+// ElasticsearchAllowedNamespacesAnnotation is the annotation of an ECK managed Elasticsearch which lists, as a
+// comma-separated list, the namespaces other than its own from which it may be referenced.  As the credentials
+// of the elastic user are copied into the namespace of the referencing resource, an Elasticsearch may only be
+// referenced from another namespace when it opts in with this annotation.
+const ElasticsearchAllowedNamespacesAnnotation = "apps.dustinscott.io/allowed-namespaces"
+
+var ErrElasticsearchRefNotAllowed = errors.New("elasticsearch does not allow references from namespace")
+
+// ElasticsearchAllowsNamespace returns whether an ECK managed Elasticsearch may be referenced from a namespace.
+// An Elasticsearch may always be referenced from its own namespace, and from the namespaces which are listed
+// in its ElasticsearchAllowedNamespacesAnnotation annotation.
+func ElasticsearchAllowsNamespace(elasticsearch *unstructured.Unstructured, namespace string) bool {
+	if elasticsearch.GetNamespace() == namespace {
+		return true
+	}
+
+	for _, allowed := range strings.Split(elasticsearch.GetAnnotations()[ElasticsearchAllowedNamespacesAnnotation], ",") {
+		if strings.TrimSpace(allowed) == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// +kubebuilder:object:generate=false
+
+// ElasticsearchDependency is a dependency on an ECK managed Elasticsearch.  It implements the workload interface
+// so that it may be returned as a dependency of a component.  The dependency is ready once the health of the
+// Elasticsearch is green or yellow.
+type ElasticsearchDependency struct {
+	unstructured.Unstructured
+}
+
+// NewElasticsearchDependency returns a dependency on an ECK managed Elasticsearch.
+func NewElasticsearchDependency(namespace, name string) *ElasticsearchDependency {
+	dependency := &ElasticsearchDependency{}
+
+	dependency.SetGroupVersionKind(ElasticsearchGroupVersionKind)
+	dependency.SetNamespace(namespace)
+	dependency.SetName(name)
+
+	return dependency
+}
+
+// GetWorkloadGVK returns a GVK object for the dependency.
+func (*ElasticsearchDependency) GetWorkloadGVK() schema.GroupVersionKind {
+	return ElasticsearchGroupVersionKind
+}
+
+// GetDependencies returns the dependencies for the dependency, which has none.
+func (*ElasticsearchDependency) GetDependencies() []workload.Workload {
+	return []workload.Workload{}
+}
+
+// GetDependencyStatus returns the dependency status for the dependency, which has no dependencies.
+func (*ElasticsearchDependency) GetDependencyStatus() bool {
+	return true
+}
+
+// GetReadyStatus returns whether the health of the Elasticsearch is green or yellow.
+func (dependency *ElasticsearchDependency) GetReadyStatus() bool {
+	health, _, _ := unstructured.NestedString(dependency.Object, "status", "health")
+
+	return health == "green" || health == "yellow"
+}
+
+// GetPhaseConditions returns the phase conditions for the dependency, which are not tracked.
+func (*ElasticsearchDependency) GetPhaseConditions() []*status.PhaseCondition {
+	return []*status.PhaseCondition{}
+}
+
+// GetChildResourceConditions returns the child resource conditions for the dependency, which are not tracked.
+func (*ElasticsearchDependency) GetChildResourceConditions() []*status.ChildResource {
+	return []*status.ChildResource{}
+}
+
+// SetReadyStatus is a no-op, as the status of the dependency is owned by ECK.
+func (*ElasticsearchDependency) SetReadyStatus(bool) {}
+
+// SetDependencyStatus is a no-op, as the status of the dependency is owned by ECK.
+func (*ElasticsearchDependency) SetDependencyStatus(bool) {}
+
+// SetPhaseCondition is a no-op, as the status of the dependency is owned by ECK.
+func (*ElasticsearchDependency) SetPhaseCondition(*status.PhaseCondition) {}
+
+// SetChildResourceCondition is a no-op, as the status of the dependency is owned by ECK.
+func (*ElasticsearchDependency) SetChildResourceCondition(*status.ChildResource) {}
@@ -0,0 +1,403 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder/mutate"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+
+// ClusterIDLabel is the label which identifies the cluster that the forwarder deployment forwards logs for.  It
+// is only set on the deployments for the clusters from the .spec.ocm.clusters field.
+const ClusterIDLabel = "apps.dustinscott.io/cluster-id"
+
+// CreateDeploymentParentName creates the Deployment resource with name parent.Name, or a Deployment resource
+// with name parent.Name + -clusterId for each cluster when the .spec.ocm.clusters field is set.
+func CreateDeploymentParentName(
+	parent *appsv1alpha1.OCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	resourceObjs := []client.Object{}
+
+	for _, cluster := range parent.GetClusters() {
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					// controlled by field: ocm.clusters.clusterId
+					"name":   DeploymentName(parent, cluster),
+					"labels": deploymentLabels(parent, cluster),
+				},
+				"spec": map[string]interface{}{
+					"selector": map[string]interface{}{
+						"matchLabels": deploymentLabels(parent, cluster),
+					},
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"labels": deploymentLabels(parent, cluster),
+						},
+						"spec": map[string]interface{}{
+							// controlled by field:
+							"serviceAccountName": parent.Name,
+							"nodeSelector": map[string]interface{}{
+								"kubernetes.io/os": "linux",
+							},
+							"affinity": map[string]interface{}{
+								"podAntiAffinity": map[string]interface{}{
+									"preferredDuringSchedulingIgnoredDuringExecution": []interface{}{
+										map[string]interface{}{
+											"weight": 100,
+											"podAffinityTerm": map[string]interface{}{
+												"topologyKey": "kubernetes.io/hostname",
+												"labelSelector": map[string]interface{}{
+													"matchExpressions": []interface{}{
+														map[string]interface{}{
+															"key":      "app.kubernetes.io/name",
+															"operator": "In",
+															"values": []interface{}{
+																// controlled by field:
+																parent.Name,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name": "forwarder",
+									// controlled by field: image, version
+									//  OCM Log Forwarder version to use, pinned to the digest which the version resolved
+									//  to in the Resolve-Image phase.
+									//
+									"image":           parent.GetDeploymentImage(),
+									"imagePullPolicy": "IfNotPresent",
+									"env": append([]interface{}{
+										// NOTE: present all config options here.  Use these as environment variables
+										//       on the deployment so that changes here result in the app realizing
+										//       those changes by restarting the managed pod.
+										map[string]interface{}{
+											"name": "OCM_CLUSTER_ID",
+											// controlled by field: ocm.clusterId
+											//  Cluster ID of the cluster to forward logs from.  This Cluster ID can be found in the OCM Console
+											//  as part of the URL when selecting the cluster.  It shows up in a form such as
+											//  '22tgckqk9c2ff3jd8ve62p0i2st14vrq'.
+											//
+											"value": cluster.ClusterId,
+										},
+										map[string]interface{}{
+											"name": "OCM_SECRET_NAME",
+											// controlled by field: ocm.secretRef
+											//  The secret should contain the OCM JSON token obtained from OpenShift Cluster Manager.  It should
+											//  have a single key/value pair with the form of clusterId=ocmTokenJson.  The clusterId
+											//  should match the .spec.ocm.clusterId field, while the ocmTokenJson value should be a
+											//  string form of the token obtained from OCM.
+											//
+											"value": cluster.SecretRef,
+										},
+										map[string]interface{}{
+											"name": "OCM_SECRET_NAMESPACE",
+											"valueFrom": map[string]interface{}{
+												"fieldRef": map[string]interface{}{
+													"fieldPath": "metadata.namespace",
+												},
+											},
+										},
+										map[string]interface{}{
+											"name": "OCM_POLL_INTERVAL_MINUTES",
+											// controlled by field: ocm.pollInternalMinutes
+											//  +kubebuilder:validation:Minimum=1
+											//  +kubebuilder:validation:Maximum=1440
+											//  How frequently, in minutes, the controller will poll the OpenShift Cluster Manager console for service logs.  Must
+											//  be in the range of 1 minute to 1440 minutes (1 day).
+											//
+											"value": parent.Spec.Ocm.PollInternalMinutes,
+										},
+										map[string]interface{}{
+											"name": "OCM_API_URL",
+											// controlled by field: ocm.apiURL
+											//  URL of the OpenShift Cluster Manager API from which service logs are retrieved.
+											//
+											"value": parent.Spec.Ocm.GetAPIURL(),
+										},
+										map[string]interface{}{
+											"name": "OCM_TOKEN_URL",
+											// controlled by field: ocm.tokenURL
+											//  URL of the SSO token endpoint which is used to exchange the OCM credentials for an
+											//  access token.
+											//
+											"value": parent.Spec.Ocm.GetTokenURL(),
+										},
+										map[string]interface{}{
+											"name": "DEBUG",
+											// controlled by field: debug
+											//  Enable debug logging on the log forwarder.
+											//
+											"value": parent.Spec.Debug,
+										},
+									}, optionalEnv(parent, cluster)...),
+									"securityContext": map[string]interface{}{
+										"allowPrivilegeEscalation": false,
+										"readOnlyRootFilesystem":   true,
+										"capabilities": map[string]interface{}{
+											"drop": []interface{}{
+												"ALL",
+											},
+										},
+										"runAsNonRoot": true,
+										"runAsGroup":   0,
+										"seccompProfile": map[string]interface{}{
+											"type": "RuntimeDefault",
+										},
+									},
+									"resources": map[string]interface{}{
+										"requests": map[string]interface{}{
+											"cpu":    "25m",
+											"memory": "32Mi",
+										},
+										"limits": map[string]interface{}{
+											"cpu":    "50m",
+											"memory": "64Mi",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		resourceObj.SetNamespace(parent.Namespace)
+
+		// controlled by field: suspend
+		//  The forwarder deployment is scaled to zero while forwarding is suspended, and its replica count is
+		//  otherwise left unmanaged, so that it may be scaled.
+		//
+		if replicas := parent.GetDeploymentReplicas(); replicas != nil {
+			if err := unstructured.SetNestedField(resourceObj.Object, int64(*replicas), "spec", "replicas"); err != nil {
+				return nil, fmt.Errorf("unable to set replicas on deployment [%s], %w", resourceObj.GetName(), err)
+			}
+		}
+
+		if err := setVolumes(resourceObj, parent); err != nil {
+			return nil, err
+		}
+
+		mutated, err := mutate.MutateDeploymentParentName(resourceObj, parent, reconciler, req)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjs = append(resourceObjs, mutated...)
+	}
+
+	return resourceObjs, nil
+}
+
+// DeploymentName returns the name of the Deployment which forwards logs for a cluster.
+func DeploymentName(parent *appsv1alpha1.OCMLogForwarder, cluster appsv1alpha1.OCMLogForwarderSpecOcmCluster) string {
+	if len(parent.Spec.Ocm.Clusters) == 0 {
+		return parent.Name
+	}
+
+	return parent.Name + "-" + cluster.ClusterId
+}
+
+// deploymentLabels returns the labels, which are also used as the selector, of the Deployment which forwards
+// logs for a cluster.  The cluster id label is not set for the single cluster from the .spec.ocm.clusterId
+// field, as the selector of an existing deployment is immutable.
+func deploymentLabels(parent *appsv1alpha1.OCMLogForwarder, cluster appsv1alpha1.OCMLogForwarderSpecOcmCluster) map[string]interface{} {
+	labels := map[string]interface{}{
+		// controlled by field:
+		"app.kubernetes.io/name": parent.Name,
+	}
+
+	if len(parent.Spec.Ocm.Clusters) > 0 {
+		// controlled by field: ocm.clusters.clusterId
+		labels[ClusterIDLabel] = cluster.ClusterId
+	}
+
+	return labels
+}
+
+// optionalEnv returns the environment for the forwarder container which is only set depending on the
+// configuration of the parent, such as the OCM authentication type, the proxy, the filter and the backends.
+func optionalEnv(parent *appsv1alpha1.OCMLogForwarder, cluster appsv1alpha1.OCMLogForwarderSpecOcmCluster) []interface{} {
+	env := ocmAuthEnv(parent, cluster)
+	env = append(env, proxyEnv(parent)...)
+	env = append(env, filterEnv(parent)...)
+
+	return append(env, backendEnv(parent, cluster)...)
+}
+
+// backendEnv returns the environment for the forwarder container which configures the backends where
+// logs are sent and stored, with the overrides of the cluster applied.  The unnamed backend from the
+// .spec.backend field is configured with the BACKEND_ prefix, while each backend from the .spec.backends
+// field is configured with the BACKEND_<NAME>_ prefix and listed, by name, in the BACKENDS variable.
+func backendEnv(parent *appsv1alpha1.OCMLogForwarder, cluster appsv1alpha1.OCMLogForwarderSpecOcmCluster) []interface{} {
+	if len(parent.Spec.Backends) == 0 {
+		return namedBackendEnv("", clusterBackend(cluster, parent.Spec.Backend))
+	}
+
+	names := make([]string, len(parent.Spec.Backends))
+	env := []interface{}{}
+
+	for i := range parent.Spec.Backends {
+		backend := parent.Spec.Backends[i]
+		names[i] = backend.Name
+
+		env = append(env, namedBackendEnv(backend.Name, clusterBackend(cluster, backend.OCMLogForwarderSpecBackend))...)
+	}
+
+	return append([]interface{}{
+		map[string]interface{}{
+			"name": "BACKENDS",
+			// controlled by field: backends.name
+			//  Names of the backends where logs are sent and stored, as a comma-separated list.
+			//
+			"value": strings.Join(names, ","),
+		},
+	}, env...)
+}
+
+// namedBackendEnv returns the environment for the forwarder container which configures a single backend.
+func namedBackendEnv(name string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	prefix := backendEnvPrefix(name)
+
+	env := []interface{}{
+		map[string]interface{}{
+			"name": prefix + "TYPE",
+			// controlled by field: backend.type
+			//  +kubebuilder:validation:Enum=elasticsearch;splunk;loki;kafka;s3
+			//  Backend type where logs are sent and stored.  Requires the configuration for the matching backend
+			//  to be set, such as backend.elasticSearch.url for 'elasticsearch', backend.splunk.url for 'splunk',
+			//  backend.loki.url for 'loki', backend.kafka.bootstrapServers for 'kafka' or backend.s3.bucket for 's3'.
+			//
+			"value": backend.Type,
+		},
+	}
+
+	switch backend.Type {
+	case appsv1alpha1.BackendTypeElasticSearch:
+		env = append(env, elasticSearchEnv(prefix, backend)...)
+
+		return append(env, elasticSearchTLSEnv(name, prefix, backend)...)
+	case appsv1alpha1.BackendTypeSplunk:
+		return append(env, splunkEnv(prefix, backend)...)
+	case appsv1alpha1.BackendTypeLoki:
+		return append(env, lokiEnv(prefix, backend)...)
+	case appsv1alpha1.BackendTypeKafka:
+		return append(env, kafkaEnv(prefix, backend)...)
+	case appsv1alpha1.BackendTypeS3:
+		return append(env, s3Env(prefix, backend)...)
+	}
+
+	return env
+}
+
+// setVolumes sets the volumes, which contain the trust material of the backends and the trusted CA bundle, on
+// the forwarder pod and mounts them read-only in the forwarder container.  The volumes are always set, as an empty
+// list when no volume is required, so that the merge patch of the deployment removes volumes which are no longer
+// required.
+func setVolumes(resourceObj *unstructured.Unstructured, parent *appsv1alpha1.OCMLogForwarder) error {
+	volumes, volumeMounts := trustedCABundleVolumes(parent)
+
+	for _, backend := range parent.GetBackends() {
+		if backend.Type != appsv1alpha1.BackendTypeElasticSearch {
+			continue
+		}
+
+		backendVolumes, backendVolumeMounts := elasticSearchTLSVolumes(backend.Name, &backend.OCMLogForwarderSpecBackend)
+
+		volumes = append(volumes, backendVolumes...)
+		volumeMounts = append(volumeMounts, backendVolumeMounts...)
+	}
+
+	if volumes == nil {
+		volumes, volumeMounts = []interface{}{}, []interface{}{}
+	}
+
+	if err := unstructured.SetNestedSlice(resourceObj.Object, volumes, "spec", "template", "spec", "volumes"); err != nil {
+		return fmt.Errorf("unable to set volumes on deployment [%s], %w", resourceObj.GetName(), err)
+	}
+
+	containers, found, err := unstructured.NestedFieldNoCopy(resourceObj.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		return fmt.Errorf("unable to find containers on deployment [%s], %w", resourceObj.GetName(), err)
+	}
+
+	for _, container := range containers.([]interface{}) {
+		if container := container.(map[string]interface{}); container["name"] == "forwarder" {
+			container["volumeMounts"] = volumeMounts
+		}
+	}
+
+	return nil
+}
+
+// clusterBackend returns a copy of a backend with the index and label overrides of a cluster applied.
+func clusterBackend(
+	cluster appsv1alpha1.OCMLogForwarderSpecOcmCluster,
+	backend appsv1alpha1.OCMLogForwarderSpecBackend,
+) *appsv1alpha1.OCMLogForwarderSpecBackend {
+	override := backend.DeepCopy()
+
+	if cluster.Index != "" {
+		override.ElasticSearch.Index = cluster.Index
+		override.ElasticSearch.IndexPattern = ""
+		override.ElasticSearch.ILM.RolloverAlias = ""
+		override.Splunk.Index = cluster.Index
+	}
+
+	if len(cluster.Labels) > 0 {
+		if override.Loki.Labels == nil {
+			override.Loki.Labels = map[string]string{}
+		}
+
+		for name, value := range cluster.Labels {
+			override.Loki.Labels[name] = value
+		}
+	}
+
+	return override
+}
+
+// backendEnvPrefix returns the prefix of the environment variables for a backend.
+func backendEnvPrefix(name string) string {
+	if name == "" {
+		return "BACKEND_"
+	}
+
+	return "BACKEND_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
@@ -17,231 +17,270 @@ limitations under the License.
 package ocmlogforwarder
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-
-	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"path"
 
 	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
-	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder/mutate"
 )
 
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// elasticSearchEnv returns the environment for the forwarder container when using 'elasticsearch'
+// as a backend.  Each variable name is prefixed with prefix.
+func elasticSearchEnv(prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	env := []interface{}{
+		map[string]interface{}{
+			"name": prefix + "ES_URL",
+			// controlled by field: backend.elasticSearch.url
+			//  URL to which to ship logs when using the 'elasticsearch' as a backend in the .spec.backend.type
+			//  field of this custom resource.
+			//
+			"value": backend.ElasticSearch.Url,
+		},
+		map[string]interface{}{
+			"name": prefix + "ES_AUTH_TYPE",
+			// controlled by field: backend.elasticSearch.authType
+			//  +kubebuilder:validation:Enum=basic;apikey
+			//  ElasticSearch authentication type to use.
+			//
+			//  * 'basic': For 'basic' authentication, the secret from .spec.backend.elasticSearch.secretRef should contain the
+			//  basic authentication information for the ElasticSearch connection containing only a single key/value pair with
+			//  the key as the username and the value as the password.
+			//
+			//  * 'apikey': For 'apikey' authentication, the API key is passed from the secret from
+			//  .spec.backend.elasticSearch.secretRef to the forwarder as environment variables.
+			//
+			"value": backend.ElasticSearch.AuthType,
+		},
+		map[string]interface{}{
+			"name": prefix + "ES_INDEX",
+			// controlled by field: backend.elasticSearch.index
+			//  +kubebuilder:validation:MaxLength=128
+			//  Index name in ElasticSearch where service logs are sent.  Index name must be 128 characters or less.
+			//
+			"value": backend.ElasticSearch.Index,
+		},
+		map[string]interface{}{
+			"name": prefix + "ES_SECRET_NAME",
+			// controlled by field: backend.elasticSearch.secretRef
+			//  The secret should contain the authentication information for the ElasticSearch connection.  See
+			//  .spec.backend.elasticSearch.authType for more information on secret requirements.  This secret
+			//  should exist in the same namespace as the OCMLogForwarder resource.
+			//
+			"value": backend.ElasticSearch.SecretRef,
+		},
+		map[string]interface{}{
+			"name": prefix + "ES_SECRET_NAMESPACE",
+			"valueFrom": map[string]interface{}{
+				"fieldRef": map[string]interface{}{
+					"fieldPath": "metadata.namespace",
+				},
+			},
+		},
+	}
+
+	if backend.ElasticSearch.AuthType == appsv1alpha1.ElasticSearchAuthTypeAPIKey {
+		env = append(env, elasticSearchAPIKeyEnv(prefix, backend)...)
+	}
 
-// CreateDeploymentParentName creates the Deployment resource with name parent.Name.
-func CreateDeploymentParentName(
-	parent *appsv1alpha1.OCMLogForwarder,
-	reconciler workload.Reconciler,
-	req *workload.Request,
-) ([]client.Object, error) {
+	return append(env, elasticSearchIndexEnv(prefix, backend)...)
+}
+
+// elasticSearchIndexEnv returns the environment for the forwarder container which configures data streams,
+// date based index naming and index lifecycle management.  The variables are only set when configured, so that they
+// take precedence over the index only when requested.
+func elasticSearchIndexEnv(prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	env := []interface{}{}
 
-	if parent.Spec.Backend.Type != "elasticsearch" {
-		return []client.Object{}, nil
+	if backend.ElasticSearch.DataStream.Enabled {
+		env = append(env, map[string]interface{}{
+			"name": prefix + "ES_DATA_STREAM",
+			// controlled by field: backend.elasticSearch.dataStream
+			//  Name of the ElasticSearch data stream to which service logs are written.
+			//
+			"value": backend.ElasticSearch.DataStream.GetName(),
+		})
 	}
 
-	var resourceObj = &unstructured.Unstructured{
-		Object: map[string]interface{}{
-			// +operator-builder:resource:field=backend.type,value=elasticsearch,include=true
-			"apiVersion": "apps/v1",
-			"kind":       "Deployment",
-			"metadata": map[string]interface{}{
-				// controlled by field:
-				"name": parent.Name,
-				"labels": map[string]interface{}{
-					// controlled by field:
-					"app.kubernetes.io/name": parent.Name,
+	if backend.ElasticSearch.IndexPattern != "" {
+		env = append(env, map[string]interface{}{
+			"name": prefix + "ES_INDEX_PATTERN",
+			// controlled by field: backend.elasticSearch.indexPattern
+			//  Index name pattern in ElasticSearch where service logs are sent, which may contain date math
+			//  within braces.
+			//
+			"value": backend.ElasticSearch.IndexPattern,
+		})
+	}
+
+	if backend.ElasticSearch.ILM.PolicyName != "" {
+		env = append(env, map[string]interface{}{
+			"name": prefix + "ES_ILM_POLICY",
+			// controlled by field: backend.elasticSearch.ilm.policyName
+			//  Name of the ElasticSearch index lifecycle policy which is applied to the indices that the
+			//  forwarder creates.
+			//
+			"value": backend.ElasticSearch.ILM.PolicyName,
+		})
+	}
+
+	if backend.ElasticSearch.ILM.RolloverAlias != "" {
+		env = append(env, map[string]interface{}{
+			"name": prefix + "ES_ROLLOVER_ALIAS",
+			// controlled by field: backend.elasticSearch.ilm.rolloverAlias
+			//  Rollover alias to which service logs are sent.
+			//
+			"value": backend.ElasticSearch.ILM.RolloverAlias,
+		})
+	}
+
+	return env
+}
+
+// elasticSearchAPIKeyEnv returns the environment for the forwarder container which passes the api key from the
+// secret when using the 'apikey' authentication type.  Each of the keys is optional, as the secret holds either
+// the encoded api key or the api key id and api key.
+func elasticSearchAPIKeyEnv(prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	env := []interface{}{}
+
+	for _, apiKey := range []struct {
+		name string
+		key  string
+	}{
+		{name: "ES_API_KEY_ENCODED", key: appsv1alpha1.ElasticSearchAPIKeyEncodedKey},
+		{name: "ES_API_KEY_ID", key: appsv1alpha1.ElasticSearchAPIKeyIDKey},
+		{name: "ES_API_KEY", key: appsv1alpha1.ElasticSearchAPIKeyKey},
+	} {
+		env = append(env, map[string]interface{}{
+			"name": prefix + apiKey.name,
+			"valueFrom": map[string]interface{}{
+				"secretKeyRef": map[string]interface{}{
+					// controlled by field: backend.elasticSearch.secretRef
+					"name":     backend.ElasticSearch.SecretRef,
+					"key":      apiKey.key,
+					"optional": true,
 				},
 			},
-			"spec": map[string]interface{}{
-				"replicas": 1,
-				"selector": map[string]interface{}{
-					"matchLabels": map[string]interface{}{
-						// controlled by field:
-						"app.kubernetes.io/name": parent.Name,
-					},
+		})
+	}
+
+	return env
+}
+
+// elasticSearchTLSEnv returns the environment for the forwarder container which points to the trust material
+// that is mounted for a backend.  Each variable name is prefixed with prefix.
+func elasticSearchTLSEnv(name, prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	esTLS := backend.ElasticSearch.TLS
+	env := []interface{}{
+		map[string]interface{}{
+			"name": prefix + "ES_TLS_INSECURE_SKIP_VERIFY",
+			// controlled by field: backend.elasticSearch.tls.insecureSkipVerify
+			//  Skip verification of the certificate presented by ElasticSearch.  This should only be used for testing.
+			//
+			"value": esTLS.InsecureSkipVerify,
+		},
+	}
+
+	if esTLS.CARef.Name != "" {
+		env = append(env, map[string]interface{}{
+			"name": prefix + "ES_TLS_CA_FILE",
+			// controlled by field: backend.elasticSearch.tls.caRef
+			"value": path.Join(elasticSearchTLSPath(name), "ca", esTLS.CARef.Key),
+		})
+	}
+
+	if esTLS.ClientCertSecretRef != "" {
+		env = append(env,
+			map[string]interface{}{
+				"name": prefix + "ES_TLS_CERT_FILE",
+				// controlled by field: backend.elasticSearch.tls.clientCertSecretRef
+				"value": path.Join(elasticSearchTLSPath(name), "client", "tls.crt"),
+			},
+			map[string]interface{}{
+				"name": prefix + "ES_TLS_KEY_FILE",
+				// controlled by field: backend.elasticSearch.tls.clientCertSecretRef
+				"value": path.Join(elasticSearchTLSPath(name), "client", "tls.key"),
+			},
+		)
+	}
+
+	return env
+}
+
+// elasticSearchTLSVolumes returns the volumes, and the read-only mounts of those volumes, which contain the
+// trust material for a backend.
+func elasticSearchTLSVolumes(name string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) (volumes, volumeMounts []interface{}) {
+	esTLS := backend.ElasticSearch.TLS
+
+	if esTLS.CARef.Name != "" {
+		source := map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{
+					"key":  esTLS.CARef.Key,
+					"path": esTLS.CARef.Key,
 				},
-				"template": map[string]interface{}{
-					"metadata": map[string]interface{}{
-						"labels": map[string]interface{}{
-							// controlled by field:
-							"app.kubernetes.io/name": parent.Name,
-						},
+			},
+		}
+
+		volume := map[string]interface{}{
+			"name": elasticSearchTLSVolumeName(name, "ca"),
+		}
+
+		if esTLS.CARef.Kind == appsv1alpha1.CARefKindConfigMap {
+			source["name"] = esTLS.CARef.Name
+			volume["configMap"] = source
+		} else {
+			source["secretName"] = esTLS.CARef.Name
+			volume["secret"] = source
+		}
+
+		volumes = append(volumes, volume)
+		volumeMounts = append(volumeMounts, map[string]interface{}{
+			"name":      elasticSearchTLSVolumeName(name, "ca"),
+			"mountPath": path.Join(elasticSearchTLSPath(name), "ca"),
+			"readOnly":  true,
+		})
+	}
+
+	if esTLS.ClientCertSecretRef != "" {
+		volumes = append(volumes, map[string]interface{}{
+			"name": elasticSearchTLSVolumeName(name, "client"),
+			"secret": map[string]interface{}{
+				"secretName": esTLS.ClientCertSecretRef,
+				"items": []interface{}{
+					map[string]interface{}{
+						"key":  "tls.crt",
+						"path": "tls.crt",
 					},
-					"spec": map[string]interface{}{
-						// controlled by field:
-						"serviceAccountName": parent.Name,
-						"nodeSelector": map[string]interface{}{
-							"kubernetes.io/os": "linux",
-						},
-						"affinity": map[string]interface{}{
-							"podAntiAffinity": map[string]interface{}{
-								"preferredDuringSchedulingIgnoredDuringExecution": []interface{}{
-									map[string]interface{}{
-										"weight": 100,
-										"podAffinityTerm": map[string]interface{}{
-											"topologyKey": "kubernetes.io/hostname",
-											"labelSelector": map[string]interface{}{
-												"matchExpressions": []interface{}{
-													map[string]interface{}{
-														"key":      "app.kubernetes.io/name",
-														"operator": "In",
-														"values": []interface{}{
-															// controlled by field:
-															parent.Name,
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"containers": []interface{}{
-							map[string]interface{}{
-								"name": "forwarder",
-								// controlled by field: version
-								//  OCM Log Forwarder version to use.  Any of the tags from the ocm-log-forwarder GitHub
-								//  repo are supported here.
-								//
-								"image":           "ghcr.io/scottd018/ocm-log-forwarder:" + parent.Spec.Version + "",
-								"imagePullPolicy": "IfNotPresent",
-								"env": []interface{}{
-									// NOTE: present all config options here.  Use these as environment variables
-									//       on the deployment so that changes here result in the app realizing
-									//       those changes by restarting the managed pod.
-									map[string]interface{}{
-										"name": "OCM_CLUSTER_ID",
-										// controlled by field: ocm.clusterId
-										//  Cluster ID of the cluster to forward logs from.  This Cluster ID can be found in the OCM Console
-										//  as part of the URL when selecting the cluster.  It shows up in a form such as
-										//  '22tgckqk9c2ff3jd8ve62p0i2st14vrq'.
-										//
-										"value": parent.Spec.Ocm.ClusterId,
-									},
-									map[string]interface{}{
-										"name": "OCM_SECRET_NAME",
-										// controlled by field: ocm.secretRef
-										//  The secret should contain the OCM JSON token obtained from OpenShift Cluster Manager.  It should
-										//  have a single key/value pair with the form of clusterId=ocmTokenJson.  The clusterId
-										//  should match the .spec.ocm.clusterId field, while the ocmTokenJson value should be a
-										//  string form of the token obtained from OCM.
-										//
-										"value": parent.Spec.Ocm.SecretRef,
-									},
-									map[string]interface{}{
-										"name": "OCM_SECRET_NAMESPACE",
-										"valueFrom": map[string]interface{}{
-											"fieldRef": map[string]interface{}{
-												"fieldPath": "metadata.namespace",
-											},
-										},
-									},
-									map[string]interface{}{
-										"name": "OCM_POLL_INTERVAL_MINUTES",
-										// controlled by field: ocm.pollInternalMinutes
-										//  +kubebuilder:validation:Minimum=1
-										//  +kubebuilder:validation:Maximum=1440
-										//  How frequently, in minutes, the controller will poll the OpenShift Cluster Manager console for service logs.  Must
-										//  be in the range of 1 minute to 1440 minutes (1 day).
-										//
-										"value": parent.Spec.Ocm.PollInternalMinutes,
-									},
-									map[string]interface{}{
-										"name": "BACKEND_TYPE",
-										// controlled by field: backend.type
-										//  +kubebuilder:validation:Enum=elasticsearch
-										//  Backend type where logs are sent and stored.  Only 'elasticsearch' supported at this time.  Requires
-										//  backend.elasticSearch.url to be set.
-										//
-										"value": parent.Spec.Backend.Type,
-									},
-									map[string]interface{}{
-										"name": "BACKEND_ES_URL",
-										// controlled by field: backend.elasticSearch.url
-										//  URL to which to ship logs when using the 'elasticsearch' as a backend in the .spec.backend.type
-										//  field of this custom resource.
-										//
-										"value": parent.Spec.Backend.ElasticSearch.Url,
-									},
-									map[string]interface{}{
-										"name": "BACKEND_ES_AUTH_TYPE",
-										// controlled by field: backend.elasticSearch.authType
-										//  +kubebuilder:validation:Enum=basic
-										//  ElasticSearch authentication type to use.  Only 'basic' supported at this time.
-										//
-										//  * 'basic': For 'basic' authentication, the secret from .spec.backend.elasticSearch.secretRef should contain the
-										//  basic authentication information for the ElasticSearch connection containing only a single key/value pair with
-										//  the key as the username and the value as the password.
-										//
-										"value": parent.Spec.Backend.ElasticSearch.AuthType,
-									},
-									map[string]interface{}{
-										"name": "BACKEND_ES_INDEX",
-										// controlled by field: backend.elasticSearch.index
-										//  +kubebuilder:validation:MaxLength=128
-										//  Index name in ElasticSearch where service logs are sent.  Index name must be 128 characters or less.
-										//
-										"value": parent.Spec.Backend.ElasticSearch.Index,
-									},
-									map[string]interface{}{
-										"name": "DEBUG",
-										// controlled by field: debug
-										//  Enable debug logging on the log forwarder.
-										//
-										"value": parent.Spec.Debug,
-									},
-									map[string]interface{}{
-										"name": "BACKEND_ES_SECRET_NAME",
-										// controlled by field: backend.elasticSearch.secretRef
-										//  The secret should contain the authentication information for the ElasticSearch connection.  See
-										//  .spec.backend.elasticSearch.authType for more information on secret requirements.  This secret
-										//  should exist in the same namespace as the OCMLogForwarder resource.
-										//
-										"value": parent.Spec.Backend.ElasticSearch.SecretRef,
-									},
-									map[string]interface{}{
-										"name": "BACKEND_ES_SECRET_NAMESPACE",
-										"valueFrom": map[string]interface{}{
-											"fieldRef": map[string]interface{}{
-												"fieldPath": "metadata.namespace",
-											},
-										},
-									},
-								},
-								"securityContext": map[string]interface{}{
-									"allowPrivilegeEscalation": false,
-									"readOnlyRootFilesystem":   true,
-									"capabilities": map[string]interface{}{
-										"drop": []interface{}{
-											"ALL",
-										},
-									},
-									"runAsNonRoot": true,
-									"runAsGroup":   0,
-									"seccompProfile": map[string]interface{}{
-										"type": "RuntimeDefault",
-									},
-								},
-								"resources": map[string]interface{}{
-									"requests": map[string]interface{}{
-										"cpu":    "25m",
-										"memory": "32Mi",
-									},
-									"limits": map[string]interface{}{
-										"cpu":    "50m",
-										"memory": "64Mi",
-									},
-								},
-							},
-						},
+					map[string]interface{}{
+						"key":  "tls.key",
+						"path": "tls.key",
 					},
 				},
 			},
-		},
+		})
+		volumeMounts = append(volumeMounts, map[string]interface{}{
+			"name":      elasticSearchTLSVolumeName(name, "client"),
+			"mountPath": path.Join(elasticSearchTLSPath(name), "client"),
+			"readOnly":  true,
+		})
 	}
 
-	resourceObj.SetNamespace(parent.Namespace)
+	return volumes, volumeMounts
+}
+
+// elasticSearchTLSPath returns the directory in the forwarder container where the trust material for a backend
+// is mounted.
+func elasticSearchTLSPath(name string) string {
+	if name == "" {
+		return "/etc/ocm-log-forwarder/backend/es/tls"
+	}
+
+	return path.Join("/etc/ocm-log-forwarder/backends", name, "es/tls")
+}
+
+// elasticSearchTLSVolumeName returns the name of a volume which contains trust material for a backend.
+func elasticSearchTLSVolumeName(name, volume string) string {
+	if name == "" {
+		return "es-tls-" + volume
+	}
 
-	return mutate.MutateDeploymentParentName(resourceObj, parent, reconciler, req)
+	return "backend-" + name + "-es-tls-" + volume
 }
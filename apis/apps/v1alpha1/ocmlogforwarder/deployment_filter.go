@@ -0,0 +1,62 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"strconv"
+	"strings"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// filterEnv returns the environment for the forwarder container which configures the filter of the service
+// logs that are forwarded.  The variables are only set when configured, so that existing deployments are left
+// unchanged.
+func filterEnv(parent *appsv1alpha1.OCMLogForwarder) []interface{} {
+	filter := parent.Spec.Filter
+	env := []interface{}{}
+
+	maxAgeMinutes := ""
+	if filter.MaxAgeMinutes > 0 {
+		maxAgeMinutes = strconv.Itoa(filter.MaxAgeMinutes)
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value string
+	}{
+		// controlled by field: filter.minSeverity
+		{name: "FILTER_MIN_SEVERITY", value: filter.MinSeverity},
+		// controlled by field: filter.includeServiceNames
+		{name: "FILTER_INCLUDE_SERVICE_NAMES", value: strings.Join(filter.IncludeServiceNames, ",")},
+		// controlled by field: filter.excludeServiceNames
+		{name: "FILTER_EXCLUDE_SERVICE_NAMES", value: strings.Join(filter.ExcludeServiceNames, ",")},
+		// controlled by field: filter.summaryRegex
+		{name: "FILTER_SUMMARY_REGEX", value: filter.SummaryRegex},
+		// controlled by field: filter.maxAgeMinutes
+		{name: "FILTER_MAX_AGE_MINUTES", value: maxAgeMinutes},
+	} {
+		if variable.value != "" {
+			env = append(env, map[string]interface{}{
+				"name":  variable.name,
+				"value": variable.value,
+			})
+		}
+	}
+
+	return env
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"strings"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// kafkaEnv returns the environment for the forwarder container when using 'kafka' as a backend.  Each
+// variable name is prefixed with prefix.
+func kafkaEnv(prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": prefix + "KAFKA_BOOTSTRAP_SERVERS",
+			// controlled by field: backend.kafka.bootstrapServers
+			//  Kafka bootstrap servers, in host:port form, to which to produce service logs.  Servers are
+			//  passed as a comma-separated list.
+			//
+			"value": strings.Join(backend.Kafka.BootstrapServers, ","),
+		},
+		map[string]interface{}{
+			"name": prefix + "KAFKA_TOPIC",
+			// controlled by field: backend.kafka.topic
+			//  Topic to which each service log is produced as a JSON record.
+			//
+			"value": backend.Kafka.Topic,
+		},
+		map[string]interface{}{
+			"name": prefix + "KAFKA_KEY_STRATEGY",
+			// controlled by field: backend.kafka.keyStrategy
+			//  +kubebuilder:validation:Enum=clusterId;logId
+			//  Strategy used to set the key of each produced record.
+			//
+			"value": backend.Kafka.KeyStrategy,
+		},
+		map[string]interface{}{
+			"name": prefix + "KAFKA_AUTH_TYPE",
+			// controlled by field: backend.kafka.authType
+			//  +kubebuilder:validation:Enum=none;scram-sha-256;scram-sha-512;mtls
+			//  Kafka authentication type to use.
+			//
+			"value": backend.Kafka.AuthType,
+		},
+		map[string]interface{}{
+			"name": prefix + "KAFKA_SECRET_NAME",
+			// controlled by field: backend.kafka.secretRef
+			//  The secret should contain the credentials for the Kafka connection.  See
+			//  .spec.backend.kafka.authType for more information on secret requirements.
+			//
+			"value": backend.Kafka.SecretRef,
+		},
+		map[string]interface{}{
+			"name": prefix + "KAFKA_SECRET_NAMESPACE",
+			"valueFrom": map[string]interface{}{
+				"fieldRef": map[string]interface{}{
+					"fieldPath": "metadata.namespace",
+				},
+			},
+		},
+	}
+}
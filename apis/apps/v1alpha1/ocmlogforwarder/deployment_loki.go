@@ -0,0 +1,92 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"sort"
+	"strings"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// lokiEnv returns the environment for the forwarder container when using 'loki' as a backend.  Each
+// variable name is prefixed with prefix.
+func lokiEnv(prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": prefix + "LOKI_URL",
+			// controlled by field: backend.loki.url
+			//  Push URL of Loki to which to ship logs when using 'loki' as a backend in the .spec.backend.type
+			//  field of this custom resource.
+			//
+			"value": backend.Loki.Url,
+		},
+		map[string]interface{}{
+			"name": prefix + "LOKI_TENANT_ID",
+			// controlled by field: backend.loki.tenantId
+			//  Tenant ID which is sent as the X-Scope-OrgID header when Loki is running in multi-tenant mode.
+			//
+			"value": backend.Loki.TenantId,
+		},
+		map[string]interface{}{
+			"name": prefix + "LOKI_LABELS",
+			// controlled by field: backend.loki.labels
+			//  Static labels which are added to the stream of each service log that is sent to Loki.  Labels
+			//  are passed as a sorted, comma-separated list of name=value pairs.
+			//
+			"value": lokiLabels(backend.Loki.Labels),
+		},
+		map[string]interface{}{
+			"name": prefix + "LOKI_AUTH_TYPE",
+			// controlled by field: backend.loki.authType
+			//  +kubebuilder:validation:Enum=none;basic;bearer
+			//  Loki authentication type to use.
+			//
+			"value": backend.Loki.AuthType,
+		},
+		map[string]interface{}{
+			"name": prefix + "LOKI_SECRET_NAME",
+			// controlled by field: backend.loki.secretRef
+			//  The secret should contain the authentication information for the Loki connection.  See
+			//  .spec.backend.loki.authType for more information on secret requirements.
+			//
+			"value": backend.Loki.SecretRef,
+		},
+		map[string]interface{}{
+			"name": prefix + "LOKI_SECRET_NAMESPACE",
+			"valueFrom": map[string]interface{}{
+				"fieldRef": map[string]interface{}{
+					"fieldPath": "metadata.namespace",
+				},
+			},
+		},
+	}
+}
+
+// lokiLabels returns the static stream labels as a sorted, comma-separated list of name=value pairs.  The
+// labels are sorted so that the rendered environment is stable across reconciles.
+func lokiLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// ocmAuthEnv returns the environment for the forwarder container which is specific to the OCM authentication
+// type.  The 'offlineToken' authentication type reads the token from the secret named by OCM_SECRET_NAME, so
+// no additional environment is needed.
+func ocmAuthEnv(parent *appsv1alpha1.OCMLogForwarder, cluster appsv1alpha1.OCMLogForwarderSpecOcmCluster) []interface{} {
+	if parent.Spec.Ocm.AuthType != appsv1alpha1.OCMAuthTypeServiceAccount {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name": "OCM_AUTH_TYPE",
+			// controlled by field: ocm.authType
+			//  OCM authentication type to use.
+			//
+			"value": parent.Spec.Ocm.AuthType,
+		},
+		map[string]interface{}{
+			"name": "OCM_CLIENT_ID",
+			"valueFrom": map[string]interface{}{
+				"secretKeyRef": map[string]interface{}{
+					// controlled by field: ocm.secretRef, ocm.clusters.secretRef
+					"name": cluster.SecretRef,
+					"key":  appsv1alpha1.OCMClientIDKey,
+				},
+			},
+		},
+		map[string]interface{}{
+			"name": "OCM_CLIENT_SECRET",
+			"valueFrom": map[string]interface{}{
+				"secretKeyRef": map[string]interface{}{
+					// controlled by field: ocm.secretRef, ocm.clusters.secretRef
+					"name": cluster.SecretRef,
+					"key":  appsv1alpha1.OCMClientSecretKey,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// s3Env returns the environment for the forwarder container when using 's3' as a backend.  Each
+// variable name is prefixed with prefix.
+func s3Env(prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": prefix + "S3_ENDPOINT",
+			// controlled by field: backend.s3.endpoint
+			//  Endpoint of the S3-compatible object storage.  When unset, the AWS S3 endpoint for the region
+			//  is used.
+			//
+			"value": backend.S3.Endpoint,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_BUCKET",
+			// controlled by field: backend.s3.bucket
+			//  Bucket in which service log archives are stored.
+			//
+			"value": backend.S3.Bucket,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_PREFIX",
+			// controlled by field: backend.s3.prefix
+			//  Template of the key prefix for each archive object.  Supports the '{clusterId}', '{year}',
+			//  '{month}' and '{day}' placeholders.
+			//
+			"value": backend.S3.Prefix,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_REGION",
+			// controlled by field: backend.s3.region
+			//  Region of the bucket.
+			//
+			"value": backend.S3.Region,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_FORCE_PATH_STYLE",
+			// controlled by field: backend.s3.forcePathStyle
+			//  Use path-style addressing rather than virtual-hosted-style addressing.
+			//
+			"value": backend.S3.ForcePathStyle,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_FORMAT",
+			// controlled by field: backend.s3.format
+			//  +kubebuilder:validation:Enum=jsonl;jsonl-gzip
+			//  Format of each archive object.
+			//
+			"value": backend.S3.Format,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_FLUSH_INTERVAL_MINUTES",
+			// controlled by field: backend.s3.flushIntervalMinutes
+			//  How frequently, in minutes, buffered service logs are flushed to a new archive object.
+			//
+			"value": backend.S3.FlushIntervalMinutes,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_SECRET_NAME",
+			// controlled by field: backend.s3.secretRef
+			//  The secret should contain the access key id at key 'AWS_ACCESS_KEY_ID' and the secret access
+			//  key at key 'AWS_SECRET_ACCESS_KEY'.
+			//
+			"value": backend.S3.SecretRef,
+		},
+		map[string]interface{}{
+			"name": prefix + "S3_SECRET_NAMESPACE",
+			"valueFrom": map[string]interface{}{
+				"fieldRef": map[string]interface{}{
+					"fieldPath": "metadata.namespace",
+				},
+			},
+		},
+	}
+}
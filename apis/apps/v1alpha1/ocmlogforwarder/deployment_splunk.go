@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// splunkEnv returns the environment for the forwarder container when using 'splunk' as a backend.  Each
+// variable name is prefixed with prefix.
+func splunkEnv(prefix string, backend *appsv1alpha1.OCMLogForwarderSpecBackend) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": prefix + "SPLUNK_URL",
+			// controlled by field: backend.splunk.url
+			//  URL of the Splunk HTTP Event Collector to which to ship logs when using 'splunk' as a backend in the
+			//  .spec.backend.type field of this custom resource.
+			//
+			"value": backend.Splunk.Url,
+		},
+		map[string]interface{}{
+			"name": prefix + "SPLUNK_INDEX",
+			// controlled by field: backend.splunk.index
+			//  Index in Splunk where service logs are sent.  If unset, the default index of the HTTP Event Collector
+			//  token is used.
+			//
+			"value": backend.Splunk.Index,
+		},
+		map[string]interface{}{
+			"name": prefix + "SPLUNK_SOURCETYPE",
+			// controlled by field: backend.splunk.sourceType
+			//  Source type which is set on the events that are sent to Splunk.
+			//
+			"value": backend.Splunk.SourceType,
+		},
+		map[string]interface{}{
+			"name": prefix + "SPLUNK_SOURCE",
+			// controlled by field: backend.splunk.source
+			//  Source which is set on the events that are sent to Splunk.
+			//
+			"value": backend.Splunk.Source,
+		},
+		map[string]interface{}{
+			"name": prefix + "SPLUNK_INSECURE_SKIP_VERIFY",
+			// controlled by field: backend.splunk.insecureSkipVerify
+			//  Skip verification of the TLS certificate presented by the Splunk HTTP Event Collector.  This should
+			//  only be used for testing.
+			//
+			"value": backend.Splunk.InsecureSkipVerify,
+		},
+		map[string]interface{}{
+			"name": prefix + "SPLUNK_SECRET_NAME",
+			// controlled by field: backend.splunk.secretRef
+			//  The secret should contain the Splunk HTTP Event Collector token used to authenticate to the Splunk
+			//  HTTP Event Collector.  It should have a single key/value pair with the form of token=hecToken.
+			//
+			"value": backend.Splunk.SecretRef,
+		},
+		map[string]interface{}{
+			"name": prefix + "SPLUNK_SECRET_NAMESPACE",
+			"valueFrom": map[string]interface{}{
+				"fieldRef": map[string]interface{}{
+					"fieldPath": "metadata.namespace",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,345 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// envValues returns the value of each variable from an environment by name.  Variables which are set from a
+// source are returned as the source.
+func envValues(env []interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+
+	for _, variable := range env {
+		variable := variable.(map[string]interface{})
+
+		if valueFrom, ok := variable["valueFrom"]; ok {
+			values[variable["name"].(string)] = valueFrom
+		} else {
+			values[variable["name"].(string)] = variable["value"]
+		}
+	}
+
+	return values
+}
+
+func TestBackendEnv(t *testing.T) {
+	elasticSearch := appsv1alpha1.OCMLogForwarderSpecBackend{
+		Type: appsv1alpha1.BackendTypeElasticSearch,
+		ElasticSearch: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch{
+			Url:          "https://es.example.com",
+			AuthType:     appsv1alpha1.ElasticSearchAuthTypeBasic,
+			Index:        "service-logs",
+			IndexPattern: "service-logs-{now/d}",
+			SecretRef:    "es-auth",
+		},
+	}
+
+	splunk := appsv1alpha1.OCMLogForwarderSpecBackend{
+		Type: appsv1alpha1.BackendTypeSplunk,
+		Splunk: appsv1alpha1.OCMLogForwarderSpecBackendSplunk{
+			Url:       "https://splunk.example.com:8088",
+			Index:     "ocm",
+			SecretRef: "splunk-token",
+		},
+	}
+
+	loki := appsv1alpha1.OCMLogForwarderSpecBackend{
+		Type: appsv1alpha1.BackendTypeLoki,
+		Loki: appsv1alpha1.OCMLogForwarderSpecBackendLoki{
+			Url:      "https://loki.example.com/loki/api/v1/push",
+			Labels:   map[string]string{"team": "sre", "env": "prod"},
+			AuthType: "none",
+		},
+	}
+
+	kafka := appsv1alpha1.OCMLogForwarderSpecBackend{
+		Type: appsv1alpha1.BackendTypeKafka,
+		Kafka: appsv1alpha1.OCMLogForwarderSpecBackendKafka{
+			BootstrapServers: []string{"kafka-0:9092", "kafka-1:9092"},
+			Topic:            "service-logs",
+		},
+	}
+
+	s3 := appsv1alpha1.OCMLogForwarderSpecBackend{
+		Type: appsv1alpha1.BackendTypeS3,
+		S3: appsv1alpha1.OCMLogForwarderSpecBackendS3{
+			Bucket: "archive",
+			Prefix: "{clusterId}/{year}",
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		backend  appsv1alpha1.OCMLogForwarderSpecBackend
+		backends []appsv1alpha1.OCMLogForwarderSpecNamedBackend
+		cluster  appsv1alpha1.OCMLogForwarderSpecOcmCluster
+		expected map[string]interface{}
+		absent   []string
+	}{
+		{
+			name:    "elasticsearch",
+			backend: elasticSearch,
+			expected: map[string]interface{}{
+				"BACKEND_TYPE":                        appsv1alpha1.BackendTypeElasticSearch,
+				"BACKEND_ES_URL":                      "https://es.example.com",
+				"BACKEND_ES_INDEX":                    "service-logs",
+				"BACKEND_ES_INDEX_PATTERN":            "service-logs-{now/d}",
+				"BACKEND_ES_SECRET_NAME":              "es-auth",
+				"BACKEND_ES_TLS_INSECURE_SKIP_VERIFY": false,
+			},
+			absent: []string{"BACKENDS", "BACKEND_ES_API_KEY", "BACKEND_ES_ROLLOVER_ALIAS", "BACKEND_ES_DATA_STREAM"},
+		},
+		{
+			name:    "elasticsearch with cluster index",
+			backend: elasticSearch,
+			cluster: appsv1alpha1.OCMLogForwarderSpecOcmCluster{ClusterId: "abc", Index: "cluster-abc"},
+			expected: map[string]interface{}{
+				"BACKEND_ES_INDEX": "cluster-abc",
+			},
+			absent: []string{"BACKEND_ES_INDEX_PATTERN"},
+		},
+		{
+			name:    "splunk",
+			backend: splunk,
+			expected: map[string]interface{}{
+				"BACKEND_TYPE":               appsv1alpha1.BackendTypeSplunk,
+				"BACKEND_SPLUNK_URL":         "https://splunk.example.com:8088",
+				"BACKEND_SPLUNK_INDEX":       "ocm",
+				"BACKEND_SPLUNK_SECRET_NAME": "splunk-token",
+			},
+			absent: []string{"BACKEND_ES_URL"},
+		},
+		{
+			name:    "splunk with cluster index",
+			backend: splunk,
+			cluster: appsv1alpha1.OCMLogForwarderSpecOcmCluster{ClusterId: "abc", Index: "cluster-abc"},
+			expected: map[string]interface{}{
+				"BACKEND_SPLUNK_INDEX": "cluster-abc",
+			},
+		},
+		{
+			name:    "loki",
+			backend: loki,
+			expected: map[string]interface{}{
+				"BACKEND_TYPE":        appsv1alpha1.BackendTypeLoki,
+				"BACKEND_LOKI_URL":    "https://loki.example.com/loki/api/v1/push",
+				"BACKEND_LOKI_LABELS": "env=prod,team=sre",
+			},
+		},
+		{
+			name:    "loki with cluster labels",
+			backend: loki,
+			cluster: appsv1alpha1.OCMLogForwarderSpecOcmCluster{ClusterId: "abc", Labels: map[string]string{"env": "dev", "cluster": "abc"}},
+			expected: map[string]interface{}{
+				"BACKEND_LOKI_LABELS": "cluster=abc,env=dev,team=sre",
+			},
+		},
+		{
+			name:    "kafka",
+			backend: kafka,
+			expected: map[string]interface{}{
+				"BACKEND_TYPE":                    appsv1alpha1.BackendTypeKafka,
+				"BACKEND_KAFKA_BOOTSTRAP_SERVERS": "kafka-0:9092,kafka-1:9092",
+				"BACKEND_KAFKA_TOPIC":             "service-logs",
+			},
+		},
+		{
+			name:    "s3",
+			backend: s3,
+			expected: map[string]interface{}{
+				"BACKEND_TYPE":      appsv1alpha1.BackendTypeS3,
+				"BACKEND_S3_BUCKET": "archive",
+				"BACKEND_S3_PREFIX": "{clusterId}/{year}",
+			},
+		},
+		{
+			name: "named backends",
+			backends: []appsv1alpha1.OCMLogForwarderSpecNamedBackend{
+				{Name: "primary-es", OCMLogForwarderSpecBackend: elasticSearch},
+				{Name: "archive", OCMLogForwarderSpecBackend: s3},
+			},
+			expected: map[string]interface{}{
+				"BACKENDS":                  "primary-es,archive",
+				"BACKEND_PRIMARY_ES_TYPE":   appsv1alpha1.BackendTypeElasticSearch,
+				"BACKEND_PRIMARY_ES_ES_URL": "https://es.example.com",
+				"BACKEND_ARCHIVE_TYPE":      appsv1alpha1.BackendTypeS3,
+				"BACKEND_ARCHIVE_S3_BUCKET": "archive",
+			},
+			absent: []string{"BACKEND_TYPE", "BACKEND_ES_URL"},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &appsv1alpha1.OCMLogForwarder{
+				Spec: appsv1alpha1.OCMLogForwarderSpec{
+					Backend:  *tt.backend.DeepCopy(),
+					Backends: tt.backends,
+				},
+			}
+
+			values := envValues(backendEnv(parent, tt.cluster))
+
+			for name, expected := range tt.expected {
+				if found, ok := values[name]; !ok || !reflect.DeepEqual(found, expected) {
+					t.Errorf("expected %s=[%v]; found [%v]", name, expected, found)
+				}
+			}
+
+			for _, name := range tt.absent {
+				if _, ok := values[name]; ok {
+					t.Errorf("expected %s to be unset; found [%v]", name, values[name])
+				}
+			}
+
+			if !reflect.DeepEqual(parent.Spec.Backend, tt.backend) {
+				t.Errorf("expected the backend of the parent to be left unchanged by the cluster overrides")
+			}
+		})
+	}
+}
+
+func TestOCMAuthEnv(t *testing.T) {
+	secretKeyRef := func(name, key string) map[string]interface{} {
+		return map[string]interface{}{
+			"secretKeyRef": map[string]interface{}{"name": name, "key": key},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		authType string
+		cluster  appsv1alpha1.OCMLogForwarderSpecOcmCluster
+		expected map[string]interface{}
+	}{
+		{name: "default", expected: map[string]interface{}{}},
+		{name: "offline token", authType: appsv1alpha1.OCMAuthTypeOfflineToken, expected: map[string]interface{}{}},
+		{
+			name:     "service account",
+			authType: appsv1alpha1.OCMAuthTypeServiceAccount,
+			cluster:  appsv1alpha1.OCMLogForwarderSpecOcmCluster{ClusterId: "abc", SecretRef: "ocm-sa"},
+			expected: map[string]interface{}{
+				"OCM_AUTH_TYPE":     appsv1alpha1.OCMAuthTypeServiceAccount,
+				"OCM_CLIENT_ID":     secretKeyRef("ocm-sa", appsv1alpha1.OCMClientIDKey),
+				"OCM_CLIENT_SECRET": secretKeyRef("ocm-sa", appsv1alpha1.OCMClientSecretKey),
+			},
+		},
+		{
+			name:     "service account with a cluster secret",
+			authType: appsv1alpha1.OCMAuthTypeServiceAccount,
+			cluster:  appsv1alpha1.OCMLogForwarderSpecOcmCluster{ClusterId: "def", SecretRef: "ocm-sa-def"},
+			expected: map[string]interface{}{
+				"OCM_AUTH_TYPE":     appsv1alpha1.OCMAuthTypeServiceAccount,
+				"OCM_CLIENT_ID":     secretKeyRef("ocm-sa-def", appsv1alpha1.OCMClientIDKey),
+				"OCM_CLIENT_SECRET": secretKeyRef("ocm-sa-def", appsv1alpha1.OCMClientSecretKey),
+			},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &appsv1alpha1.OCMLogForwarder{
+				Spec: appsv1alpha1.OCMLogForwarderSpec{
+					Ocm: appsv1alpha1.OCMLogForwarderSpecOcm{AuthType: tt.authType, SecretRef: "ocm-sa"},
+				},
+			}
+
+			if found := envValues(ocmAuthEnv(parent, tt.cluster)); !reflect.DeepEqual(found, tt.expected) {
+				t.Errorf("expected %v; found %v", tt.expected, found)
+			}
+		})
+	}
+}
+
+func TestSetVolumes(t *testing.T) {
+	tls := appsv1alpha1.OCMLogForwarderSpecBackendElasticSearchTLS{
+		CARef:               appsv1alpha1.OCMLogForwarderSpecBackendElasticSearchTLSCARef{Kind: appsv1alpha1.CARefKindSecret, Name: "es-ca", Key: "ca.crt"},
+		ClientCertSecretRef: "es-client",
+	}
+
+	for _, tt := range []struct {
+		name     string
+		tls      appsv1alpha1.OCMLogForwarderSpecBackendElasticSearchTLS
+		proxy    appsv1alpha1.OCMLogForwarderSpecProxy
+		expected int
+	}{
+		{name: "tls", tls: tls, expected: 2},
+		{name: "trusted ca bundle", proxy: appsv1alpha1.OCMLogForwarderSpecProxy{InjectTrustedCABundle: true}, expected: 1},
+		{name: "tls and trusted ca bundle", tls: tls, proxy: appsv1alpha1.OCMLogForwarderSpecProxy{InjectTrustedCABundle: true}, expected: 3},
+		{name: "tls removed", expected: 0},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &appsv1alpha1.OCMLogForwarder{
+				Spec: appsv1alpha1.OCMLogForwarderSpec{
+					Backend: appsv1alpha1.OCMLogForwarderSpecBackend{
+						Type:          appsv1alpha1.BackendTypeElasticSearch,
+						ElasticSearch: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch{TLS: tt.tls},
+					},
+					Proxy: tt.proxy,
+				},
+			}
+
+			resourceObj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"template": map[string]interface{}{
+							"spec": map[string]interface{}{
+								"containers": []interface{}{
+									map[string]interface{}{"name": "forwarder"},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			if err := setVolumes(resourceObj, parent); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// the volumes must always be set, as the merge patch of the deployment otherwise leaves the
+			// volumes of a previous generation in place
+			volumes, found, err := unstructured.NestedFieldNoCopy(resourceObj.Object, "spec", "template", "spec", "volumes")
+			if err != nil || !found {
+				t.Fatalf("expected volumes to be set; found [%v], %v", volumes, err)
+			}
+
+			if found := len(volumes.([]interface{})); found != tt.expected {
+				t.Errorf("expected [%d] volumes; found [%d]", tt.expected, found)
+			}
+
+			containers, found, err := unstructured.NestedFieldNoCopy(resourceObj.Object, "spec", "template", "spec", "containers")
+			if err != nil || !found {
+				t.Fatalf("expected containers to be set; found [%v], %v", containers, err)
+			}
+
+			container := containers.([]interface{})[0].(map[string]interface{})
+			if mounts, ok := container["volumeMounts"].([]interface{}); !ok || len(mounts) != tt.expected {
+				t.Errorf("expected [%d] volume mounts; found [%v]", tt.expected, container["volumeMounts"])
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+const (
+	// elasticSearchRolloverMaxAge is the maximum age of an index before it is rolled over when using a rollover
+	// alias.
+	elasticSearchRolloverMaxAge = "1d"
+
+	// elasticSearchRolloverMaxPrimaryShardSize is the maximum size of the primary shard of an index before it
+	// is rolled over when using a rollover alias.
+	elasticSearchRolloverMaxPrimaryShardSize = "50gb"
+
+	// elasticSearchTemplatePriority is the priority of the index templates which are managed by the operator,
+	// so that they take precedence over the built-in index templates of ElasticSearch.
+	elasticSearchTemplatePriority = 200
+)
+
+// ElasticSearchProvisioning is the index template, and optionally the index lifecycle policy, which are
+// provisioned in ElasticSearch for an elasticsearch backend which has manageIndexTemplate or a data stream
+// enabled.
+type ElasticSearchProvisioning struct {
+	// Field is the field path of the backend.
+	Field string
+
+	// ElasticSearch is the elasticsearch configuration of the backend, with any referenced ECK managed
+	// Elasticsearch resolved.
+	ElasticSearch appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch
+
+	// TemplateName is the name of the index template.
+	TemplateName string
+
+	// Template is the body of the index template.
+	Template map[string]interface{}
+
+	// PolicyName is the name of the index lifecycle policy.  Policy is nil when the policy is not managed by
+	// the operator.
+	PolicyName string
+
+	// Policy is the body of the index lifecycle policy.
+	Policy map[string]interface{}
+
+	// RolloverAlias is the rollover alias which must be bootstrapped with an initial write index.
+	RolloverAlias string
+}
+
+// ElasticSearchProvisionings returns the index templates and index lifecycle policies which are provisioned
+// in ElasticSearch for the elasticsearch backends of a parent.
+func ElasticSearchProvisionings(
+	parent *appsv1alpha1.OCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]ElasticSearchProvisioning, error) {
+	// resolve any referenced elasticsearch on a copy so that the workload itself is not modified.
+	resolved := parent.DeepCopy()
+	if err := resolveElasticsearchRefs(resolved, reconciler, req); err != nil {
+		return nil, err
+	}
+
+	provisionings := []ElasticSearchProvisioning{}
+
+	for _, backend := range resolved.GetBackends() {
+		if backend.Type != appsv1alpha1.BackendTypeElasticSearch {
+			continue
+		}
+
+		// a data stream requires a matching index template, so it is always managed for a data stream.
+		if !backend.ElasticSearch.ManageIndexTemplate && !backend.ElasticSearch.DataStream.Enabled {
+			continue
+		}
+
+		provisionings = append(provisionings, elasticSearchProvisioning(resolved, backend))
+	}
+
+	return provisionings, nil
+}
+
+// elasticSearchProvisioning returns the index template and index lifecycle policy for a single backend.
+func elasticSearchProvisioning(
+	parent *appsv1alpha1.OCMLogForwarder,
+	backend appsv1alpha1.OCMLogForwarderSpecNamedBackend,
+) ElasticSearchProvisioning {
+	elasticSearch := backend.ElasticSearch
+
+	// the names of index templates and index lifecycle policies are global to the ElasticSearch cluster, so
+	// the namespace of the parent is included.
+	name := "ocm-service-logs-" + parent.Namespace + "-" + backendResourceName(parent, backend)
+
+	provisioning := ElasticSearchProvisioning{
+		Field:         backend.GetFieldPath(),
+		ElasticSearch: elasticSearch,
+		TemplateName:  name,
+		PolicyName:    elasticSearch.ILM.PolicyName,
+		RolloverAlias: elasticSearch.ILM.RolloverAlias,
+	}
+
+	if elasticSearch.Retention != "" || elasticSearch.ILM.RolloverAlias != "" || elasticSearch.DataStream.Enabled {
+		if provisioning.PolicyName == "" {
+			provisioning.PolicyName = name
+		}
+
+		provisioning.Policy = elasticSearchPolicy(elasticSearch)
+	}
+
+	settings := map[string]interface{}{}
+
+	if provisioning.PolicyName != "" {
+		settings["index.lifecycle.name"] = provisioning.PolicyName
+	}
+
+	if provisioning.RolloverAlias != "" {
+		settings["index.lifecycle.rollover_alias"] = provisioning.RolloverAlias
+	}
+
+	provisioning.Template = map[string]interface{}{
+		// controlled by field: backend.elasticSearch.index, backend.elasticSearch.indexPattern,
+		// backend.elasticSearch.ilm.rolloverAlias, backend.elasticSearch.dataStream, ocm.clusters.index
+		"index_patterns": elasticSearchIndexPatterns(parent, backend),
+		"priority":       elasticSearchTemplatePriority,
+		"template": map[string]interface{}{
+			"settings": settings,
+			"mappings": elasticSearchMappings(elasticSearch.DataStream.Enabled),
+		},
+		"_meta": map[string]interface{}{
+			"managed_by": "ocm-log-forwarder-operator",
+			"namespace":  parent.Namespace,
+			"name":       parent.Name,
+		},
+	}
+
+	if elasticSearch.DataStream.Enabled {
+		provisioning.Template["data_stream"] = map[string]interface{}{}
+	}
+
+	return provisioning
+}
+
+// elasticSearchIndexPatterns returns the index patterns which match the indices that service logs are sent to
+// for each cluster, taking into account the index overrides of each cluster.
+func elasticSearchIndexPatterns(
+	parent *appsv1alpha1.OCMLogForwarder,
+	backend appsv1alpha1.OCMLogForwarderSpecNamedBackend,
+) []interface{} {
+	patterns := []interface{}{}
+	found := map[string]bool{}
+
+	for _, cluster := range parent.GetClusters() {
+		pattern := clusterBackend(cluster, backend.OCMLogForwarderSpecBackend).ElasticSearch.GetIndexTemplatePattern()
+
+		if !found[pattern] {
+			found[pattern] = true
+
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+// elasticSearchPolicy returns the body of the index lifecycle policy for a backend, which rolls over the
+// write index when using a rollover alias or data stream and deletes indices once they reach the retention age.
+func elasticSearchPolicy(elasticSearch appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch) map[string]interface{} {
+	phases := map[string]interface{}{}
+
+	if elasticSearch.ILM.RolloverAlias != "" || elasticSearch.DataStream.Enabled {
+		phases["hot"] = map[string]interface{}{
+			"min_age": "0ms",
+			"actions": map[string]interface{}{
+				"rollover": map[string]interface{}{
+					"max_age":                elasticSearchRolloverMaxAge,
+					"max_primary_shard_size": elasticSearchRolloverMaxPrimaryShardSize,
+				},
+			},
+		}
+	}
+
+	if elasticSearch.Retention != "" {
+		phases["delete"] = map[string]interface{}{
+			// controlled by field: backend.elasticSearch.retention
+			"min_age": elasticSearch.Retention,
+			"actions": map[string]interface{}{
+				"delete": map[string]interface{}{},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"policy": map[string]interface{}{
+			"phases": phases,
+			"_meta": map[string]interface{}{
+				"managed_by": "ocm-log-forwarder-operator",
+			},
+		},
+	}
+}
+
+// elasticSearchMappings returns the explicit mappings for the fields of OCM service logs, so that the
+// fields have consistent types regardless of the first service log which is sent to an index.  A data
+// stream additionally requires the '@timestamp' field.
+func elasticSearchMappings(dataStream bool) map[string]interface{} {
+	keyword := map[string]interface{}{"type": "keyword"}
+	date := map[string]interface{}{"type": "date"}
+	text := map[string]interface{}{
+		"type": "text",
+		"fields": map[string]interface{}{
+			"keyword": map[string]interface{}{
+				"type":         "keyword",
+				"ignore_above": 1024,
+			},
+		},
+	}
+
+	properties := map[string]interface{}{
+		"id":              keyword,
+		"kind":            keyword,
+		"href":            keyword,
+		"timestamp":       date,
+		"created_at":      date,
+		"created_by":      keyword,
+		"cluster_id":      keyword,
+		"cluster_uuid":    keyword,
+		"subscription_id": keyword,
+		"service_name":    keyword,
+		"severity":        keyword,
+		"log_type":        keyword,
+		"event_stream_id": keyword,
+		"username":        keyword,
+		"email":           keyword,
+		"internal_only":   map[string]interface{}{"type": "boolean"},
+		"doc_references":  keyword,
+		"summary":         text,
+		"description":     text,
+	}
+
+	if dataStream {
+		properties["@timestamp"] = date
+	}
+
+	return map[string]interface{}{
+		"properties": properties,
+	}
+}
@@ -24,8 +24,8 @@ import (
 	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
 )
 
-// MutateRoleBindingParentNameElastic mutates the RoleBinding resource with name parent.name + -elastic.
-func MutateRoleBindingParentNameElastic(
+// MutateConfigMapParentNameTrustedCA mutates the ConfigMap resource with name parent.name + -trusted-ca.
+func MutateConfigMapParentNameTrustedCA(
 	original client.Object,
 	parent *appsv1alpha1.OCMLogForwarder,
 	reconciler workload.Reconciler, req *workload.Request,
@@ -17,11 +17,17 @@ limitations under the License.
 package mutate
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
@@ -32,6 +38,10 @@ import (
 
 const (
 	logForwarderContainerName = "forwarder"
+
+	// SecretHashAnnotation is the pod template annotation which stores a hash of the contents of the
+	// secrets referenced by the parent.  A change in the hash causes the forwarder pod to be restarted.
+	SecretHashAnnotation = "apps.dustinscott.io/secret-hash"
 )
 
 // This is needed in order for the operator to update finalizers
@@ -104,6 +114,16 @@ func MutateDeploymentParentName(
 	if err := unstructured.SetNestedSlice(objectMap, containers, "spec", "template", "spec", "containers"); err != nil {
 		return returnError("unable to set containers", original)
 	}
+	// stamp the hash of the referenced secrets on the pod template so that rotating a secret results
+	// in a rolling restart of the forwarder
+	hash, err := secretHash(parent, reconciler, req)
+	if err != nil {
+		return []client.Object{original}, err
+	}
+
+	if err := unstructured.SetNestedField(objectMap, hash, "spec", "template", "metadata", "annotations", SecretHashAnnotation); err != nil {
+		return returnError("unable to set secret hash annotation", original)
+	}
 	object.Object = objectMap
 
 	// attempt to create a typed deployment object before continuing
@@ -115,6 +135,40 @@ func MutateDeploymentParentName(
 	return []client.Object{deployment}, nil
 }
 
+// secretHash returns a hash of the contents of the secrets which are referenced by the parent.  Secrets
+// which do not yet exist are included by name only, so that their creation also changes the hash.
+func secretHash(
+	parent *appsv1alpha1.OCMLogForwarder,
+	reconciler workload.Reconciler, req *workload.Request,
+) (string, error) {
+	hash := sha256.New()
+
+	for _, name := range parent.GetSecretRefs() {
+		secret := &corev1.Secret{}
+
+		if err := reconciler.Get(req.Context, types.NamespacedName{Name: name, Namespace: parent.Namespace}, secret); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return "", fmt.Errorf("unable to retrieve secret [%s/%s], %w", parent.Namespace, name, err)
+			}
+		}
+
+		keys := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		fmt.Fprintf(hash, "%s\n", name)
+
+		for _, key := range keys {
+			fmt.Fprintf(hash, "%s=%x\n", key, secret.Data[key])
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func returnError(message string, object client.Object) ([]client.Object, error) {
 	return []client.Object{object}, fmt.Errorf(
 		"%s for object [%s/%s] of kind [%s]",
@@ -0,0 +1,122 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/fakereconciler"
+)
+
+func TestSecretHash(t *testing.T) {
+	parent := &appsv1alpha1.OCMLogForwarder{
+		ObjectMeta: metav1.ObjectMeta{Name: "forwarder", Namespace: "ocm"},
+		Spec: appsv1alpha1.OCMLogForwarderSpec{
+			Ocm: appsv1alpha1.OCMLogForwarderSpecOcm{SecretRef: "ocm-token"},
+			Backend: appsv1alpha1.OCMLogForwarderSpecBackend{
+				Type:          appsv1alpha1.BackendTypeElasticSearch,
+				ElasticSearch: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch{SecretRef: "es-auth"},
+			},
+		},
+	}
+
+	secret := func(name string, data map[string]string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ocm"},
+			Data:       map[string][]byte{},
+		}
+
+		for key, value := range data {
+			secret.Data[key] = []byte(value)
+		}
+
+		return secret
+	}
+
+	hash := func(t *testing.T, secrets ...*corev1.Secret) string {
+		t.Helper()
+
+		reconciler := fakereconciler.New()
+		for _, secret := range secrets {
+			if err := reconciler.Create(context.TODO(), secret.DeepCopy()); err != nil {
+				t.Fatalf("unable to create secret: %v", err)
+			}
+		}
+
+		found, err := secretHash(parent, reconciler, fakereconciler.NewRequest(parent))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return found
+	}
+
+	ocmToken := secret("ocm-token", map[string]string{"ocmToken": "token"})
+	esAuth := secret("es-auth", map[string]string{"elastic": "password", "other": "value"})
+	base := hash(t, ocmToken, esAuth)
+
+	for _, tt := range []struct {
+		name    string
+		secrets []*corev1.Secret
+		changed bool
+	}{
+		{name: "unchanged", secrets: []*corev1.Secret{ocmToken, esAuth}},
+		{name: "unreferenced secret", secrets: []*corev1.Secret{ocmToken, esAuth, secret("unrelated", map[string]string{"a": "b"})}},
+		{
+			name:    "rotated ocm token",
+			secrets: []*corev1.Secret{secret("ocm-token", map[string]string{"ocmToken": "rotated"}), esAuth},
+			changed: true,
+		},
+		{
+			name:    "rotated backend password",
+			secrets: []*corev1.Secret{ocmToken, secret("es-auth", map[string]string{"elastic": "rotated", "other": "value"})},
+			changed: true,
+		},
+		{
+			name:    "renamed key",
+			secrets: []*corev1.Secret{ocmToken, secret("es-auth", map[string]string{"admin": "password", "other": "value"})},
+			changed: true,
+		},
+		{name: "missing secret", secrets: []*corev1.Secret{ocmToken}, changed: true},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			found := hash(t, tt.secrets...)
+
+			if tt.changed && found == base {
+				t.Errorf("expected hash to change from [%s]", base)
+			}
+
+			if !tt.changed && found != base {
+				t.Errorf("expected hash [%s]; found [%s]", base, found)
+			}
+		})
+	}
+
+	// the hash must be stable across repeated calls, as map iteration order is random
+	for i := 0; i < 10; i++ {
+		if found := hash(t, ocmToken, esAuth); found != base {
+			t.Fatalf("expected stable hash [%s]; found [%s]", base, found)
+		}
+	}
+}
@@ -24,8 +24,8 @@ import (
 	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
 )
 
-// MutateRoleParentNameElastic mutates the Role resource with name parent.name + -elastic.
-func MutateRoleParentNameElastic(
+// MutateRoleParentNameBackend mutates a Role resource with name parent.name + -backendName.
+func MutateRoleParentNameBackend(
 	original client.Object,
 	parent *appsv1alpha1.OCMLogForwarder,
 	reconciler workload.Reconciler, req *workload.Request,
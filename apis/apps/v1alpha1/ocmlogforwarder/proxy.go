@@ -0,0 +1,189 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"fmt"
+	"path"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder/mutate"
+)
+
+const (
+	// TrustedCABundleInjectLabel is the label which causes OpenShift to inject the trusted CA bundle of the
+	// cluster into a ConfigMap.
+	TrustedCABundleInjectLabel = "config.openshift.io/inject-trusted-cabundle"
+
+	// trustedCABundleKey is the key of the trusted CA bundle in the injected ConfigMap.
+	trustedCABundleKey = "ca-bundle.crt"
+
+	// trustedCABundlePath is the directory in the forwarder container where the trusted CA bundle is mounted.
+	trustedCABundlePath = "/etc/ocm-log-forwarder/trusted-ca"
+
+	// trustedCABundleVolumeName is the name of the volume which contains the trusted CA bundle.
+	trustedCABundleVolumeName = "trusted-ca"
+)
+
+// resolveClusterProxy sets the proxy values of a parent from the cluster-wide proxy configuration when the
+// parent inherits the cluster proxy and has no proxy values of its own.  The effective values are read from
+// the status of the cluster proxy, which includes the hosts that OpenShift adds to noProxy.  Nothing is
+// inherited when the cluster is not OpenShift, or when the reconciler or request are nil, such as when
+// generating from the CLI.
+func resolveClusterProxy(
+	parent *appsv1alpha1.OCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) error {
+	proxy := &parent.Spec.Proxy
+	if !proxy.InheritClusterProxy || proxy.IsSet() || reconciler == nil || req == nil {
+		return nil
+	}
+
+	clusterProxy := &unstructured.Unstructured{}
+	clusterProxy.SetGroupVersionKind(appsv1alpha1.ClusterProxyGroupVersionKind)
+
+	if err := reconciler.Get(req.Context, types.NamespacedName{Name: appsv1alpha1.ClusterProxyName}, clusterProxy); err != nil {
+		if meta.IsNoMatchError(err) || apierrs.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to retrieve cluster proxy [%s], %w", appsv1alpha1.ClusterProxyName, err)
+	}
+
+	proxy.HttpProxy, _, _ = unstructured.NestedString(clusterProxy.Object, "status", "httpProxy")
+	proxy.HttpsProxy, _, _ = unstructured.NestedString(clusterProxy.Object, "status", "httpsProxy")
+	proxy.NoProxy, _, _ = unstructured.NestedString(clusterProxy.Object, "status", "noProxy")
+
+	return nil
+}
+
+// proxyEnv returns the environment for the forwarder container which configures the proxy and the trusted
+// CA bundle.  The variables are only set when configured, so that existing deployments are left unchanged.
+func proxyEnv(parent *appsv1alpha1.OCMLogForwarder) []interface{} {
+	env := []interface{}{}
+
+	for _, variable := range []struct {
+		name  string
+		value string
+	}{
+		// controlled by field: proxy.httpProxy
+		{name: "HTTP_PROXY", value: parent.Spec.Proxy.HttpProxy},
+		// controlled by field: proxy.httpsProxy
+		{name: "HTTPS_PROXY", value: parent.Spec.Proxy.HttpsProxy},
+		// controlled by field: proxy.noProxy
+		{name: "NO_PROXY", value: parent.Spec.Proxy.NoProxy},
+	} {
+		if variable.value != "" {
+			env = append(env, map[string]interface{}{
+				"name":  variable.name,
+				"value": variable.value,
+			})
+		}
+	}
+
+	if parent.Spec.Proxy.InjectTrustedCABundle {
+		env = append(env, map[string]interface{}{
+			"name": "SSL_CERT_FILE",
+			// controlled by field: proxy.injectTrustedCABundle
+			"value": path.Join(trustedCABundlePath, trustedCABundleKey),
+		})
+	}
+
+	return env
+}
+
+// trustedCABundleVolumes returns the volume, and the read-only mount of the volume, which contains the trusted
+// CA bundle.  The volume is optional as the bundle is injected asynchronously after the ConfigMap is created.
+func trustedCABundleVolumes(parent *appsv1alpha1.OCMLogForwarder) (volumes, volumeMounts []interface{}) {
+	if !parent.Spec.Proxy.InjectTrustedCABundle {
+		return nil, nil
+	}
+
+	volumes = []interface{}{
+		map[string]interface{}{
+			"name": trustedCABundleVolumeName,
+			"configMap": map[string]interface{}{
+				"name":     trustedCABundleConfigMapName(parent),
+				"optional": true,
+				"items": []interface{}{
+					map[string]interface{}{
+						"key":  trustedCABundleKey,
+						"path": trustedCABundleKey,
+					},
+				},
+			},
+		},
+	}
+
+	volumeMounts = []interface{}{
+		map[string]interface{}{
+			"name":      trustedCABundleVolumeName,
+			"mountPath": trustedCABundlePath,
+			"readOnly":  true,
+		},
+	}
+
+	return volumes, volumeMounts
+}
+
+// trustedCABundleConfigMapName returns the name of the ConfigMap which the trusted CA bundle is injected into.
+func trustedCABundleConfigMapName(parent *appsv1alpha1.OCMLogForwarder) string {
+	return parent.Name + "-trusted-ca"
+}
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get;list;watch
+
+// CreateConfigMapParentNameTrustedCA creates the ConfigMap resource, with name parent.name + -trusted-ca, which
+// OpenShift injects the trusted CA bundle of the cluster into.  The data of the ConfigMap is owned by OpenShift,
+// so only the injection label is set.
+func CreateConfigMapParentNameTrustedCA(
+	parent *appsv1alpha1.OCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	if !parent.Spec.Proxy.InjectTrustedCABundle {
+		return []client.Object{}, nil
+	}
+
+	var resourceObj = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				// controlled by field: proxy.injectTrustedCABundle
+				"name": trustedCABundleConfigMapName(parent),
+				"labels": map[string]interface{}{
+					TrustedCABundleInjectLabel: "true",
+				},
+			},
+		},
+	}
+
+	resourceObj.SetNamespace(parent.Namespace)
+
+	return mutate.MutateConfigMapParentNameTrustedCA(resourceObj, parent, reconciler, req)
+}
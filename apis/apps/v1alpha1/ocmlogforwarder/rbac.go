@@ -55,12 +55,16 @@ func CreateServiceAccountParentName(
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;watch;list
 
-// CreateRoleParentNameOcm creates the Role resource with name parent.name + -ocm.
+// CreateRoleParentNameOcm creates the Role resource with name parent.name + -ocm, when the forwarder reads
+// the OCM secrets using the Kubernetes API.
 func CreateRoleParentNameOcm(
 	parent *appsv1alpha1.OCMLogForwarder,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
+	if !ocmSecretAccessRequired(parent) {
+		return []client.Object{}, nil
+	}
 
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -83,10 +87,8 @@ func CreateRoleParentNameOcm(
 						"watch",
 						"list",
 					},
-					"resourceNames": []interface{}{
-						// controlled by field: ocm.secretRef
-						parent.Spec.Ocm.SecretRef,
-					},
+					// controlled by field: ocm.secretRef, ocm.clusters.secretRef
+					"resourceNames": resourceNames(parent.GetOCMSecretRefs()),
 				},
 			},
 		},
@@ -100,61 +102,74 @@ func CreateRoleParentNameOcm(
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;watch;list
 
-// CreateRoleParentNameElastic creates the Role resource with name parent.name + -elastic.
-func CreateRoleParentNameElastic(
+// CreateRoleParentNameBackend creates a Role resource, with name parent.name + -backendName, for each
+// backend which references secrets.
+func CreateRoleParentNameBackend(
 	parent *appsv1alpha1.OCMLogForwarder,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
-
-	if parent.Spec.Backend.Type != "elasticsearch" {
-		return []client.Object{}, nil
-	}
-
-	var resourceObj = &unstructured.Unstructured{
-		Object: map[string]interface{}{
-			// +operator-builder:resource:field=backend.type,value=elasticsearch,include=true
-			"apiVersion": "rbac.authorization.k8s.io/v1",
-			"kind":       "Role",
-			"metadata": map[string]interface{}{
-				// controlled by field:
-				"name": "" + parent.Name + "-elastic",
-			},
-			"rules": []interface{}{
-				map[string]interface{}{
-					"apiGroups": []interface{}{
-						"",
-					},
-					"resources": []interface{}{
-						"secrets",
-					},
-					"verbs": []interface{}{
-						"get",
-						"watch",
-						"list",
-					},
-					"resourceNames": []interface{}{
-						// controlled by field: backend.elasticSearch.secretRef
-						parent.Spec.Backend.ElasticSearch.SecretRef,
+	resourceObjs := []client.Object{}
+
+	for _, backend := range parent.GetBackends() {
+		secretRefs := backend.GetSecretRefs()
+		if len(secretRefs) == 0 {
+			continue
+		}
+
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "rbac.authorization.k8s.io/v1",
+				"kind":       "Role",
+				"metadata": map[string]interface{}{
+					// controlled by field: backends.name
+					"name": backendResourceName(parent, backend),
+				},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"apiGroups": []interface{}{
+							"",
+						},
+						"resources": []interface{}{
+							"secrets",
+						},
+						"verbs": []interface{}{
+							"get",
+							"watch",
+							"list",
+						},
+						// controlled by field: backend.<type>.secretRef, backend.elasticSearch.tls
+						"resourceNames": resourceNames(secretRefs),
 					},
 				},
 			},
-		},
-	}
+		}
 
-	resourceObj.SetNamespace(parent.Namespace)
+		resourceObj.SetNamespace(parent.Namespace)
 
-	return mutate.MutateRoleParentNameElastic(resourceObj, parent, reconciler, req)
+		mutated, err := mutate.MutateRoleParentNameBackend(resourceObj, parent, reconciler, req)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjs = append(resourceObjs, mutated...)
+	}
+
+	return resourceObjs, nil
 }
 
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 
-// CreateRoleBindingParentNameOcm creates the RoleBinding resource with name parent.name + -ocm.
+// CreateRoleBindingParentNameOcm creates the RoleBinding resource with name parent.name + -ocm, when the
+// forwarder reads the OCM secrets using the Kubernetes API.
 func CreateRoleBindingParentNameOcm(
 	parent *appsv1alpha1.OCMLogForwarder,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
+	if !ocmSecretAccessRequired(parent) {
+		return []client.Object{}, nil
+	}
 
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -187,43 +202,87 @@ func CreateRoleBindingParentNameOcm(
 
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 
-// CreateRoleBindingParentNameElastic creates the RoleBinding resource with name parent.name + -elastic.
-func CreateRoleBindingParentNameElastic(
+// CreateRoleBindingParentNameBackend creates a RoleBinding resource, with name parent.name + -backendName, for
+// each backend which references secrets.
+func CreateRoleBindingParentNameBackend(
 	parent *appsv1alpha1.OCMLogForwarder,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
+	resourceObjs := []client.Object{}
+
+	for _, backend := range parent.GetBackends() {
+		if len(backend.GetSecretRefs()) == 0 {
+			continue
+		}
+
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "rbac.authorization.k8s.io/v1",
+				"kind":       "RoleBinding",
+				"metadata": map[string]interface{}{
+					// controlled by field: backends.name
+					"name": backendResourceName(parent, backend),
+				},
+				"roleRef": map[string]interface{}{
+					"apiGroup": "rbac.authorization.k8s.io",
+					"kind":     "Role",
+					// controlled by field: backends.name
+					"name": backendResourceName(parent, backend),
+				},
+				"subjects": []interface{}{
+					map[string]interface{}{
+						"kind": "ServiceAccount",
+						// controlled by field:
+						"name": parent.Name,
+					},
+				},
+			},
+		}
 
-	if parent.Spec.Backend.Type != "elasticsearch" {
-		return []client.Object{}, nil
+		resourceObj.SetNamespace(parent.Namespace)
+
+		mutated, err := mutate.MutateRoleBindingParentNameBackend(resourceObj, parent, reconciler, req)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjs = append(resourceObjs, mutated...)
 	}
 
-	var resourceObj = &unstructured.Unstructured{
-		Object: map[string]interface{}{
-			// +operator-builder:resource:field=backend.type,value=elasticsearch,include=true
-			"apiVersion": "rbac.authorization.k8s.io/v1",
-			"kind":       "RoleBinding",
-			"metadata": map[string]interface{}{
-				// controlled by field:
-				"name": "" + parent.Name + "-elastic",
-			},
-			"roleRef": map[string]interface{}{
-				"apiGroup": "rbac.authorization.k8s.io",
-				"kind":     "Role",
-				// controlled by field:
-				"name": "" + parent.Name + "-elastic",
-			},
-			"subjects": []interface{}{
-				map[string]interface{}{
-					"kind": "ServiceAccount",
-					// controlled by field:
-					"name": parent.Name,
-				},
-			},
-		},
+	return resourceObjs, nil
+}
+
+// resourceNames returns the resource names of a role rule from a list of names.
+func resourceNames(names []string) []interface{} {
+	resourceNames := make([]interface{}, len(names))
+
+	for i := range names {
+		resourceNames[i] = names[i]
 	}
 
-	resourceObj.SetNamespace(parent.Namespace)
+	return resourceNames
+}
 
-	return mutate.MutateRoleBindingParentNameElastic(resourceObj, parent, reconciler, req)
+// ocmSecretAccessRequired returns whether the forwarder reads the OCM secrets using the Kubernetes API.  The
+// credentials of a service account are instead passed to the forwarder from the secret as environment
+// variables, so the forwarder does not need access to the secret.
+func ocmSecretAccessRequired(parent *appsv1alpha1.OCMLogForwarder) bool {
+	return parent.Spec.Ocm.AuthType != appsv1alpha1.OCMAuthTypeServiceAccount
+}
+
+// backendResourceName returns the name of the resources which are created for a backend.  The unnamed backend
+// from the .spec.backend field keeps the names from prior to the introduction of the .spec.backends field, so
+// that upgrading does not orphan existing resources.
+func backendResourceName(parent *appsv1alpha1.OCMLogForwarder, backend appsv1alpha1.OCMLogForwarderSpecNamedBackend) string {
+	if backend.Name != "" {
+		return parent.Name + "-backend-" + backend.Name
+	}
+
+	switch backend.Type {
+	case appsv1alpha1.BackendTypeElasticSearch:
+		return parent.Name + "-elastic"
+	default:
+		return parent.Name + "-" + backend.Type
+	}
 }
@@ -36,17 +36,83 @@ metadata:
 spec:
   ocm:
     secretRef: "ocm-token"
+    authType: "offlineToken"
     clusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"
     pollInternalMinutes: 5
+    apiURL: "https://api.openshift.com"
+    tokenURL: "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
   backend:
     elasticSearch:
       secretRef: "elastic-auth"
       url: "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200"
       authType: "basic"
       index: "ocm_service_logs"
+      indexPattern: ""
+      ilm:
+        policyName: ""
+        rolloverAlias: ""
+      dataStream:
+        enabled: false
+        namespace: "default"
+      manageIndexTemplate: false
+      retention: ""
+      tls:
+        caRef:
+          kind: "Secret"
+          name: ""
+          key: "ca.crt"
+        clientCertSecretRef: ""
+        insecureSkipVerify: false
+      elasticsearchRef:
+        name: ""
+        namespace: ""
+    splunk:
+      secretRef: "splunk-hec-token"
+      url: "https://splunk.example.com:8088"
+      index: "ocm_service_logs"
+      sourceType: "ocm:servicelog"
+      source: "ocm-log-forwarder"
+      insecureSkipVerify: false
+    loki:
+      secretRef: "loki-auth"
+      url: "http://loki-gateway.loki.svc.cluster.local/loki/api/v1/push"
+      tenantId: "ocm"
+      labels:
+        source: "ocm"
+      authType: "none"
+    kafka:
+      secretRef: "kafka-auth"
+      bootstrapServers:
+        - "kafka-kafka-bootstrap.kafka.svc.cluster.local:9093"
+      topic: "ocm-service-logs"
+      keyStrategy: "clusterId"
+      authType: "scram-sha-512"
+    s3:
+      secretRef: "s3-credentials"
+      endpoint: "https://minio.minio.svc.cluster.local:9000"
+      bucket: "ocm-service-logs"
+      prefix: "ocm-service-logs/{clusterId}/{year}/{month}/{day}"
+      region: "us-east-1"
+      forcePathStyle: true
+      format: "jsonl-gzip"
+      flushIntervalMinutes: 60
     type: "elasticsearch"
+  proxy:
+    httpProxy: ""
+    httpsProxy: ""
+    noProxy: ""
+    inheritClusterProxy: false
+    injectTrustedCABundle: false
+  filter:
+    minSeverity: "Info"
+    includeServiceNames: []
+    excludeServiceNames: []
+    summaryRegex: ""
+    maxAgeMinutes: 1440
   version: "latest"
+  image: "ghcr.io/scottd018/ocm-log-forwarder"
   debug: false
+  suspend: false
 `
 
 // sampleOCMLogForwarderRequired is a sample containing only required fields
@@ -76,10 +142,25 @@ func Generate(
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
+	if err := workloadObj.ValidateSpec(); err != nil {
+		return nil, err
+	}
+
+	// resolve any referenced elasticsearch and the cluster proxy on a copy so that the workload itself is
+	// not modified.
+	resolvedObj := workloadObj.DeepCopy()
+	if err := resolveElasticsearchRefs(resolvedObj, reconciler, req); err != nil {
+		return nil, err
+	}
+
+	if err := resolveClusterProxy(resolvedObj, reconciler, req); err != nil {
+		return nil, err
+	}
+
 	resourceObjects := []client.Object{}
 
 	for _, f := range CreateFuncs {
-		resources, err := f(&workloadObj, reconciler, req)
+		resources, err := f(resolvedObj, reconciler, req)
 
 		if err != nil {
 			return nil, err
@@ -103,6 +184,9 @@ func GenerateForCLI(workloadFile []byte) ([]client.Object, error) {
 		return nil, fmt.Errorf("error validating workload yaml, %w", err)
 	}
 
+	// the defaults of the custom resource definition are not applied to a manifest which is read from a file.
+	workloadObj.Spec.SetDefaults()
+
 	return Generate(workloadObj, nil, nil)
 }
 
@@ -115,10 +199,12 @@ var CreateFuncs = []func(
 	*workload.Request,
 ) ([]client.Object, error){
 	CreateServiceAccountParentName,
+	CreateSecretParentNameElasticsearchRef,
+	CreateConfigMapParentNameTrustedCA,
 	CreateRoleParentNameOcm,
-	CreateRoleParentNameElastic,
+	CreateRoleParentNameBackend,
 	CreateRoleBindingParentNameOcm,
-	CreateRoleBindingParentNameElastic,
+	CreateRoleBindingParentNameBackend,
 	CreateDeploymentParentName,
 }
 
@@ -0,0 +1,89 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGenerateForCLI(t *testing.T) {
+	for _, requiredOnly := range []bool{false, true} {
+		resources, err := GenerateForCLI([]byte(Sample(requiredOnly)))
+		if err != nil {
+			t.Errorf("required only [%t]: unexpected error: %v", requiredOnly, err)
+
+			continue
+		}
+
+		found := false
+
+		for _, resource := range resources {
+			found = found || resource.GetObjectKind().GroupVersionKind().Kind == "Deployment"
+		}
+
+		if !found {
+			t.Errorf("required only [%t]: expected a deployment to be generated", requiredOnly)
+		}
+	}
+}
+
+func TestGenerateForCLIDefaults(t *testing.T) {
+	resources, err := GenerateForCLI([]byte(Sample(true)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, resource := range resources {
+		object, ok := resource.(*unstructured.Unstructured)
+		if !ok {
+			t.Fatalf("expected an unstructured object; found %T", resource)
+		}
+
+		switch object.GetKind() {
+		case "Deployment":
+			// the values are not deep copied, as the generated objects contain values such as int.
+			containers, _, _ := unstructured.NestedFieldNoCopy(object.Object, "spec", "template", "spec", "containers")
+			env := map[string]string{}
+
+			for _, variable := range containers.([]interface{})[0].(map[string]interface{})["env"].([]interface{}) {
+				variable := variable.(map[string]interface{})
+				env[variable["name"].(string)] = fmt.Sprint(variable["value"])
+			}
+
+			for name, want := range map[string]string{
+				"OCM_SECRET_NAME":           "ocm-token",
+				"OCM_POLL_INTERVAL_MINUTES": "5",
+				"OCM_API_URL":               "https://api.openshift.com",
+			} {
+				if env[name] != want {
+					t.Errorf("expected %s [%s]; found [%s]", name, want, env[name])
+				}
+			}
+		case "Role":
+			rules, _, _ := unstructured.NestedFieldNoCopy(object.Object, "rules")
+
+			for _, rule := range rules.([]interface{}) {
+				if names, ok := rule.(map[string]interface{})["resourceNames"]; ok && len(names.([]interface{})) == 0 {
+					t.Errorf("expected role [%s] to be restricted to named resources; found %v", object.GetName(), rule)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder/mutate"
+)
+
+// elasticsearchRefSecret is a secret which is copied from the namespace of an ECK managed Elasticsearch.
+type elasticsearchRefSecret struct {
+	name   string
+	source string
+	key    string
+}
+
+// resolveElasticsearchRefs resolves the url, credentials and certificate authority of each elasticsearch
+// backend which references an ECK managed Elasticsearch.  The credentials and certificate authority are
+// copied into secrets in the namespace of the parent by CreateSecretParentNameElasticsearchRef.
+func resolveElasticsearchRefs(
+	parent *appsv1alpha1.OCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) error {
+	if len(parent.Spec.Backends) == 0 {
+		return resolveElasticsearchRef(parent, "", &parent.Spec.Backend, reconciler, req)
+	}
+
+	for i := range parent.Spec.Backends {
+		backend := &parent.Spec.Backends[i]
+
+		if err := resolveElasticsearchRef(parent, backend.Name, &backend.OCMLogForwarderSpecBackend, reconciler, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveElasticsearchRef resolves the url, credentials and certificate authority of a single backend.  When
+// the reconciler or request are nil, such as when generating from the CLI, TLS is assumed to be enabled on the
+// http layer of the referenced Elasticsearch.  An Elasticsearch in another namespace must allow references from
+// the namespace of the parent, as its credentials are copied into the namespace of the parent.
+func resolveElasticsearchRef(
+	parent *appsv1alpha1.OCMLogForwarder,
+	name string,
+	backend *appsv1alpha1.OCMLogForwarderSpecBackend,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) error {
+	ref := backend.ElasticSearch.ElasticsearchRef
+	if backend.Type != appsv1alpha1.BackendTypeElasticSearch || ref.Name == "" {
+		return nil
+	}
+
+	namespace := parent.GetElasticsearchRefNamespace(ref)
+	tlsEnabled := true
+
+	if reconciler != nil && req != nil {
+		elasticsearch := &unstructured.Unstructured{}
+		elasticsearch.SetGroupVersionKind(appsv1alpha1.ElasticsearchGroupVersionKind)
+
+		if err := reconciler.Get(req.Context, types.NamespacedName{Namespace: namespace, Name: ref.Name}, elasticsearch); err != nil {
+			return fmt.Errorf("unable to retrieve elasticsearch [%s/%s], %w", namespace, ref.Name, err)
+		}
+
+		if !appsv1alpha1.ElasticsearchAllowsNamespace(elasticsearch, parent.Namespace) {
+			return fmt.Errorf(
+				"%w [%s]; elasticsearch [%s/%s] must list the namespace in its [%s] annotation",
+				appsv1alpha1.ErrElasticsearchRefNotAllowed,
+				parent.Namespace,
+				namespace,
+				ref.Name,
+				appsv1alpha1.ElasticsearchAllowedNamespacesAnnotation,
+			)
+		}
+
+		disabled, _, _ := unstructured.NestedBool(elasticsearch.Object, "spec", "http", "tls", "selfSignedCertificate", "disabled")
+		tlsEnabled = !disabled
+	}
+
+	named := appsv1alpha1.OCMLogForwarderSpecNamedBackend{Name: name, OCMLogForwarderSpecBackend: *backend}
+	scheme := "http"
+
+	if tlsEnabled {
+		scheme = "https"
+		backend.ElasticSearch.TLS.CARef = appsv1alpha1.OCMLogForwarderSpecBackendElasticSearchTLSCARef{
+			Kind: appsv1alpha1.CARefKindSecret,
+			Name: elasticsearchRefSecretName(parent, named, "ca"),
+			Key:  appsv1alpha1.ElasticsearchRefCAKey,
+		}
+	} else {
+		backend.ElasticSearch.TLS.CARef = appsv1alpha1.OCMLogForwarderSpecBackendElasticSearchTLSCARef{}
+	}
+
+	backend.ElasticSearch.Url = fmt.Sprintf("%s://%s-es-http.%s.svc:9200", scheme, ref.Name, namespace)
+	backend.ElasticSearch.AuthType = appsv1alpha1.ElasticSearchAuthTypeBasic
+	backend.ElasticSearch.SecretRef = elasticsearchRefSecretName(parent, named, "user")
+
+	return nil
+}
+
+// elasticsearchRefSecretName returns the name of a secret which is copied from an ECK managed Elasticsearch.
+func elasticsearchRefSecretName(
+	parent *appsv1alpha1.OCMLogForwarder,
+	backend appsv1alpha1.OCMLogForwarderSpecNamedBackend,
+	suffix string,
+) string {
+	return backendResourceName(parent, backend) + "-eck-" + suffix
+}
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
+
+// CreateSecretParentNameElasticsearchRef creates the Secret resources which hold the credentials, and the
+// certificate authority, of each ECK managed Elasticsearch that is referenced by a backend.  The secrets are
+// copied from the namespace of the Elasticsearch so that the forwarder only reads secrets from the namespace
+// of the parent.  Expects the backends of the parent to have been resolved by resolveElasticsearchRefs.
+func CreateSecretParentNameElasticsearchRef(
+	parent *appsv1alpha1.OCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	resourceObjs := []client.Object{}
+
+	// the secrets are copied from the cluster, so there is nothing to generate without a reconciler.
+	if reconciler == nil || req == nil {
+		return resourceObjs, nil
+	}
+
+	for _, backend := range parent.GetBackends() {
+		ref := backend.ElasticSearch.ElasticsearchRef
+		if backend.Type != appsv1alpha1.BackendTypeElasticSearch || ref.Name == "" {
+			continue
+		}
+
+		namespace := parent.GetElasticsearchRefNamespace(ref)
+
+		copies := []elasticsearchRefSecret{
+			{
+				// controlled by field: backend.elasticSearch.elasticsearchRef
+				name:   backend.ElasticSearch.SecretRef,
+				source: ref.UserSecretName(),
+				key:    appsv1alpha1.ElasticsearchRefUserKey,
+			},
+		}
+
+		if caRef := backend.ElasticSearch.TLS.CARef; caRef.Name != "" {
+			copies = append(copies, elasticsearchRefSecret{
+				// controlled by field: backend.elasticSearch.elasticsearchRef
+				name:   caRef.Name,
+				source: ref.CASecretName(),
+				key:    appsv1alpha1.ElasticsearchRefCAKey,
+			})
+		}
+
+		for _, secretCopy := range copies {
+			source := &corev1.Secret{}
+			if err := reconciler.Get(req.Context, types.NamespacedName{Namespace: namespace, Name: secretCopy.source}, source); err != nil {
+				return nil, fmt.Errorf("unable to retrieve elasticsearch secret [%s/%s], %w", namespace, secretCopy.source, err)
+			}
+
+			value, ok := source.Data[secretCopy.key]
+			if !ok {
+				return nil, fmt.Errorf(
+					"elasticsearch secret [%s/%s] is missing key [%s]",
+					namespace,
+					secretCopy.source,
+					secretCopy.key,
+				)
+			}
+
+			var resourceObj = &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"type":       "Opaque",
+					"metadata": map[string]interface{}{
+						"name": secretCopy.name,
+					},
+					"data": map[string]interface{}{
+						secretCopy.key: base64.StdEncoding.EncodeToString(value),
+					},
+				},
+			}
+
+			resourceObj.SetNamespace(parent.Namespace)
+
+			mutated, err := mutate.MutateSecretParentNameElasticsearchRef(resourceObj, parent, reconciler, req)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceObjs = append(resourceObjs, mutated...)
+		}
+	}
+
+	return resourceObjs, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/fakereconciler"
+)
+
+func TestResolveElasticsearchRef(t *testing.T) {
+	elasticsearch := func(namespace string, annotations map[string]string) *unstructured.Unstructured {
+		elasticsearch := &unstructured.Unstructured{}
+		elasticsearch.SetGroupVersionKind(appsv1alpha1.ElasticsearchGroupVersionKind)
+		elasticsearch.SetNamespace(namespace)
+		elasticsearch.SetName("logging")
+		elasticsearch.SetAnnotations(annotations)
+
+		return elasticsearch
+	}
+
+	for _, tt := range []struct {
+		name          string
+		namespace     string
+		elasticsearch *unstructured.Unstructured
+		expectedURL   string
+		wantErr       error
+	}{
+		{
+			name:          "same namespace",
+			elasticsearch: elasticsearch("ocm", nil),
+			expectedURL:   "https://logging-es-http.ocm.svc:9200",
+		},
+		{
+			name:          "explicit same namespace",
+			namespace:     "ocm",
+			elasticsearch: elasticsearch("ocm", nil),
+			expectedURL:   "https://logging-es-http.ocm.svc:9200",
+		},
+		{
+			name:          "cross namespace without opt in",
+			namespace:     "elastic",
+			elasticsearch: elasticsearch("elastic", nil),
+			wantErr:       appsv1alpha1.ErrElasticsearchRefNotAllowed,
+		},
+		{
+			name:      "cross namespace with other namespaces allowed",
+			namespace: "elastic",
+			elasticsearch: elasticsearch("elastic", map[string]string{
+				appsv1alpha1.ElasticsearchAllowedNamespacesAnnotation: "team-a,team-b",
+			}),
+			wantErr: appsv1alpha1.ErrElasticsearchRefNotAllowed,
+		},
+		{
+			name:      "cross namespace with opt in",
+			namespace: "elastic",
+			elasticsearch: elasticsearch("elastic", map[string]string{
+				appsv1alpha1.ElasticsearchAllowedNamespacesAnnotation: "team-a, ocm",
+			}),
+			expectedURL: "https://logging-es-http.elastic.svc:9200",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			parent := &appsv1alpha1.OCMLogForwarder{
+				ObjectMeta: metav1.ObjectMeta{Name: "forwarder", Namespace: "ocm"},
+			}
+
+			backend := &appsv1alpha1.OCMLogForwarderSpecBackend{
+				Type: appsv1alpha1.BackendTypeElasticSearch,
+				ElasticSearch: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch{
+					ElasticsearchRef: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearchRef{
+						Name:      "logging",
+						Namespace: tt.namespace,
+					},
+				},
+			}
+
+			reconciler := fakereconciler.New(tt.elasticsearch)
+
+			err := resolveElasticsearchRef(parent, "", backend, reconciler, fakereconciler.NewRequest(parent))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error [%v]; found [%v]", tt.wantErr, err)
+				}
+
+				if backend.ElasticSearch.SecretRef != "" {
+					t.Errorf("expected the credentials not to be resolved; found [%s]", backend.ElasticSearch.SecretRef)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if backend.ElasticSearch.Url != tt.expectedURL {
+				t.Errorf("expected url [%s]; found [%s]", tt.expectedURL, backend.ElasticSearch.Url)
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	appsv1beta1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1"
+)
+
+// ConversionDataAnnotation is the annotation which holds the spec of the version that an OCMLogForwarder was
+// converted from, when that spec can not be represented by the version that it was converted to.  The spec is
+// restored from the annotation when converting back, so that conversion round trips without losing data.
+const ConversionDataAnnotation = "apps.dustinscott.io/conversion-data"
+
+// backendTypes maps the backend types of this version to the backend types of the hub version.
+var backendTypes = map[string]string{
+	BackendTypeElasticSearch: appsv1beta1.BackendTypeElasticSearch,
+	BackendTypeSplunk:        appsv1beta1.BackendTypeSplunk,
+	BackendTypeLoki:          appsv1beta1.BackendTypeLoki,
+	BackendTypeKafka:         appsv1beta1.BackendTypeKafka,
+	BackendTypeS3:            appsv1beta1.BackendTypeS3,
+}
+
+// ConvertTo converts this OCMLogForwarder to the hub version.
+func (component *OCMLogForwarder) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*appsv1beta1.OCMLogForwarder)
+	if !ok {
+		return fmt.Errorf("%w, unexpected hub type %T", ErrUnableToConvertOCMLogForwarder, hub)
+	}
+
+	src := component.DeepCopy()
+
+	restored := &appsv1beta1.OCMLogForwarderSpec{}
+
+	hasRestored, err := getConversionData(&src.ObjectMeta, restored)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = specToHub(&src.Spec)
+	dst.Status = statusToHub(&src.Status)
+
+	if hasRestored {
+		restoreHubSpec(&dst.Spec, restored)
+	}
+
+	converted := specFromHub(&dst.Spec)
+	if equality.Semantic.DeepEqual(&converted, &src.Spec) {
+		return nil
+	}
+
+	return setConversionData(&dst.ObjectMeta, &src.Spec)
+}
+
+// ConvertFrom converts the hub version to this OCMLogForwarder.
+func (component *OCMLogForwarder) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*appsv1beta1.OCMLogForwarder)
+	if !ok {
+		return fmt.Errorf("%w, unexpected hub type %T", ErrUnableToConvertOCMLogForwarder, hub)
+	}
+
+	src = src.DeepCopy()
+
+	restored := &OCMLogForwarderSpec{}
+
+	hasRestored, err := getConversionData(&src.ObjectMeta, restored)
+	if err != nil {
+		return err
+	}
+
+	component.ObjectMeta = src.ObjectMeta
+	component.Spec = specFromHub(&src.Spec)
+	component.Status = statusFromHub(&src.Status)
+
+	if hasRestored {
+		restoreSpec(&component.Spec, restored)
+	}
+
+	converted := specToHub(&component.Spec)
+	if equality.Semantic.DeepEqual(&converted, &src.Spec) {
+		return nil
+	}
+
+	return setConversionData(&component.ObjectMeta, &src.Spec)
+}
+
+// getConversionData reads the spec which is stored in the conversion data annotation into spec, and removes
+// the annotation.  It returns whether the annotation was found.
+func getConversionData(meta *metav1.ObjectMeta, spec interface{}) (bool, error) {
+	data, ok := meta.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	delete(meta.Annotations, ConversionDataAnnotation)
+
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	if err := json.Unmarshal([]byte(data), spec); err != nil {
+		return false, fmt.Errorf("unable to read annotation %s, %w", ConversionDataAnnotation, err)
+	}
+
+	return true, nil
+}
+
+// setConversionData stores spec in the conversion data annotation.
+func setConversionData(meta *metav1.ObjectMeta, spec interface{}) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("unable to write annotation %s, %w", ConversionDataAnnotation, err)
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+
+	meta.Annotations[ConversionDataAnnotation] = string(data)
+
+	return nil
+}
+
+// restoreHubSpec restores the parts of the hub spec which can not be represented by this version from the
+// spec which was stored before converting, unless they have since been changed.
+func restoreHubSpec(spec, restored *appsv1beta1.OCMLogForwarderSpec) {
+	if equality.Semantic.DeepEqual(ocmFromHub(&restored.Ocm), ocmFromHub(&spec.Ocm)) {
+		spec.Ocm = restored.Ocm
+	}
+
+	restoredBackend, restoredBackends := backendsFromHub(restored.Backends)
+	backend, backends := backendsFromHub(spec.Backends)
+
+	if equality.Semantic.DeepEqual(restoredBackend, backend) && equality.Semantic.DeepEqual(restoredBackends, backends) {
+		spec.Backends = restored.Backends
+	}
+}
+
+// restoreSpec restores the parts of the spec which can not be represented by the hub version, such as the
+// configuration of the backends which are not selected by their type, from the spec which was stored before
+// converting, unless they have since been changed.
+func restoreSpec(spec, restored *OCMLogForwarderSpec) {
+	if equality.Semantic.DeepEqual(ocmToHub(&restored.Ocm), ocmToHub(&spec.Ocm)) {
+		spec.Ocm = restored.Ocm
+	}
+
+	if equality.Semantic.DeepEqual(backendsToHub(restored), backendsToHub(spec)) {
+		spec.Backend = restored.Backend
+		spec.Backends = restored.Backends
+	}
+}
+
+func specToHub(spec *OCMLogForwarderSpec) appsv1beta1.OCMLogForwarderSpec {
+	return appsv1beta1.OCMLogForwarderSpec{
+		Ocm:      ocmToHub(&spec.Ocm),
+		Backends: backendsToHub(spec),
+		Proxy:    appsv1beta1.OCMLogForwarderSpecProxy(spec.Proxy),
+		Filter:   appsv1beta1.OCMLogForwarderSpecFilter(spec.Filter),
+		Version:  spec.Version,
+		Image:    spec.Image,
+		Debug:    spec.Debug,
+		Suspend:  spec.Suspend,
+	}
+}
+
+func specFromHub(spec *appsv1beta1.OCMLogForwarderSpec) OCMLogForwarderSpec {
+	backend, backends := backendsFromHub(spec.Backends)
+
+	return OCMLogForwarderSpec{
+		Ocm:      ocmFromHub(&spec.Ocm),
+		Backend:  backend,
+		Backends: backends,
+		Proxy:    OCMLogForwarderSpecProxy(spec.Proxy),
+		Filter:   OCMLogForwarderSpecFilter(spec.Filter),
+		Version:  spec.Version,
+		Image:    spec.Image,
+		Debug:    spec.Debug,
+		Suspend:  spec.Suspend,
+	}
+}
+
+func ocmToHub(ocm *OCMLogForwarderSpecOcm) appsv1beta1.OCMLogForwarderSpecOcm {
+	hub := appsv1beta1.OCMLogForwarderSpecOcm{
+		SecretRef:    ocm.SecretRef,
+		AuthType:     ocm.AuthType,
+		ClusterId:    ocm.ClusterId,
+		PollInterval: metav1.Duration{Duration: time.Duration(ocm.PollInternalMinutes) * time.Minute},
+		ApiURL:       ocm.ApiURL,
+		TokenURL:     ocm.TokenURL,
+	}
+
+	if ocm.Clusters != nil {
+		hub.Clusters = make([]appsv1beta1.OCMLogForwarderSpecOcmCluster, len(ocm.Clusters))
+
+		for i := range ocm.Clusters {
+			hub.Clusters[i] = appsv1beta1.OCMLogForwarderSpecOcmCluster(ocm.Clusters[i])
+		}
+	}
+
+	return hub
+}
+
+// ocmFromHub converts the OCM configuration of the hub version.  The poll interval is rounded up to whole
+// minutes.
+func ocmFromHub(hub *appsv1beta1.OCMLogForwarderSpecOcm) OCMLogForwarderSpecOcm {
+	ocm := OCMLogForwarderSpecOcm{
+		SecretRef:           hub.SecretRef,
+		AuthType:            hub.AuthType,
+		ClusterId:           hub.ClusterId,
+		PollInternalMinutes: int((hub.PollInterval.Duration + time.Minute - 1) / time.Minute),
+		ApiURL:              hub.ApiURL,
+		TokenURL:            hub.TokenURL,
+	}
+
+	if hub.Clusters != nil {
+		ocm.Clusters = make([]OCMLogForwarderSpecOcmCluster, len(hub.Clusters))
+
+		for i := range hub.Clusters {
+			ocm.Clusters[i] = OCMLogForwarderSpecOcmCluster(hub.Clusters[i])
+		}
+	}
+
+	return ocm
+}
+
+// backendsToHub converts the backends to the backend unions of the hub version.  When .spec.backends is unset,
+// the single backend from .spec.backend is converted to a backend named 'default'.
+func backendsToHub(spec *OCMLogForwarderSpec) []appsv1beta1.OCMLogForwarderSpecBackend {
+	if len(spec.Backends) == 0 {
+		return []appsv1beta1.OCMLogForwarderSpecBackend{backendToHub(appsv1beta1.DefaultBackendName, &spec.Backend)}
+	}
+
+	hub := make([]appsv1beta1.OCMLogForwarderSpecBackend, len(spec.Backends))
+
+	for i := range spec.Backends {
+		hub[i] = backendToHub(spec.Backends[i].Name, &spec.Backends[i].OCMLogForwarderSpecBackend)
+	}
+
+	return hub
+}
+
+// backendsFromHub converts the backend unions of the hub version.  A single backend named 'default' is
+// converted to .spec.backend, while any other backends are converted to .spec.backends.
+func backendsFromHub(hub []appsv1beta1.OCMLogForwarderSpecBackend) (OCMLogForwarderSpecBackend, []OCMLogForwarderSpecNamedBackend) {
+	if len(hub) == 1 && hub[0].Name == appsv1beta1.DefaultBackendName {
+		return backendFromHub(&hub[0]), nil
+	}
+
+	var backends []OCMLogForwarderSpecNamedBackend
+
+	for i := range hub {
+		backends = append(backends, OCMLogForwarderSpecNamedBackend{
+			Name:                       hub[i].Name,
+			OCMLogForwarderSpecBackend: backendFromHub(&hub[i]),
+		})
+	}
+
+	return OCMLogForwarderSpecBackend{}, backends
+}
+
+// backendToHub converts a backend to a backend union of the hub version, which only contains the configuration
+// of the backend that is selected by the type.
+func backendToHub(name string, backend *OCMLogForwarderSpecBackend) appsv1beta1.OCMLogForwarderSpecBackend {
+	hub := appsv1beta1.OCMLogForwarderSpecBackend{
+		Name: name,
+		Type: backendTypes[backend.Type],
+	}
+
+	switch backend.Type {
+	case BackendTypeElasticSearch:
+		hub.ElasticSearch = elasticSearchToHub(&backend.ElasticSearch)
+	case BackendTypeSplunk:
+		splunk := appsv1beta1.OCMLogForwarderSpecBackendSplunk(backend.Splunk)
+		hub.Splunk = &splunk
+	case BackendTypeLoki:
+		loki := appsv1beta1.OCMLogForwarderSpecBackendLoki(backend.Loki)
+		hub.Loki = &loki
+	case BackendTypeKafka:
+		kafka := appsv1beta1.OCMLogForwarderSpecBackendKafka(backend.Kafka)
+		hub.Kafka = &kafka
+	case BackendTypeS3:
+		s3 := appsv1beta1.OCMLogForwarderSpecBackendS3(backend.S3)
+		hub.S3 = &s3
+	}
+
+	return hub
+}
+
+func backendFromHub(hub *appsv1beta1.OCMLogForwarderSpecBackend) OCMLogForwarderSpecBackend {
+	backend := OCMLogForwarderSpecBackend{}
+
+	for backendType, hubBackendType := range backendTypes {
+		if hubBackendType == hub.Type {
+			backend.Type = backendType
+		}
+	}
+
+	if hub.ElasticSearch != nil {
+		backend.ElasticSearch = elasticSearchFromHub(hub.ElasticSearch)
+	}
+
+	if hub.Splunk != nil {
+		backend.Splunk = OCMLogForwarderSpecBackendSplunk(*hub.Splunk)
+	}
+
+	if hub.Loki != nil {
+		backend.Loki = OCMLogForwarderSpecBackendLoki(*hub.Loki)
+	}
+
+	if hub.Kafka != nil {
+		backend.Kafka = OCMLogForwarderSpecBackendKafka(*hub.Kafka)
+	}
+
+	if hub.S3 != nil {
+		backend.S3 = OCMLogForwarderSpecBackendS3(*hub.S3)
+	}
+
+	return backend
+}
+
+func elasticSearchToHub(elasticSearch *OCMLogForwarderSpecBackendElasticSearch) *appsv1beta1.OCMLogForwarderSpecBackendElasticSearch {
+	return &appsv1beta1.OCMLogForwarderSpecBackendElasticSearch{
+		SecretRef:           elasticSearch.SecretRef,
+		Url:                 elasticSearch.Url,
+		AuthType:            elasticSearch.AuthType,
+		Index:               elasticSearch.Index,
+		IndexPattern:        elasticSearch.IndexPattern,
+		ILM:                 appsv1beta1.OCMLogForwarderSpecBackendElasticSearchILM(elasticSearch.ILM),
+		DataStream:          appsv1beta1.OCMLogForwarderSpecBackendElasticSearchDataStream(elasticSearch.DataStream),
+		ManageIndexTemplate: elasticSearch.ManageIndexTemplate,
+		Retention:           elasticSearch.Retention,
+		TLS: appsv1beta1.OCMLogForwarderSpecBackendElasticSearchTLS{
+			CARef:               appsv1beta1.OCMLogForwarderSpecBackendElasticSearchTLSCARef(elasticSearch.TLS.CARef),
+			ClientCertSecretRef: elasticSearch.TLS.ClientCertSecretRef,
+			InsecureSkipVerify:  elasticSearch.TLS.InsecureSkipVerify,
+		},
+		ElasticsearchRef: appsv1beta1.OCMLogForwarderSpecBackendElasticSearchRef(elasticSearch.ElasticsearchRef),
+	}
+}
+
+func elasticSearchFromHub(hub *appsv1beta1.OCMLogForwarderSpecBackendElasticSearch) OCMLogForwarderSpecBackendElasticSearch {
+	return OCMLogForwarderSpecBackendElasticSearch{
+		SecretRef:           hub.SecretRef,
+		Url:                 hub.Url,
+		AuthType:            hub.AuthType,
+		Index:               hub.Index,
+		IndexPattern:        hub.IndexPattern,
+		ILM:                 OCMLogForwarderSpecBackendElasticSearchILM(hub.ILM),
+		DataStream:          OCMLogForwarderSpecBackendElasticSearchDataStream(hub.DataStream),
+		ManageIndexTemplate: hub.ManageIndexTemplate,
+		Retention:           hub.Retention,
+		TLS: OCMLogForwarderSpecBackendElasticSearchTLS{
+			CARef:               OCMLogForwarderSpecBackendElasticSearchTLSCARef(hub.TLS.CARef),
+			ClientCertSecretRef: hub.TLS.ClientCertSecretRef,
+			InsecureSkipVerify:  hub.TLS.InsecureSkipVerify,
+		},
+		ElasticsearchRef: OCMLogForwarderSpecBackendElasticSearchRef(hub.ElasticsearchRef),
+	}
+}
+
+func statusToHub(status *OCMLogForwarderStatus) appsv1beta1.OCMLogForwarderStatus {
+	hub := appsv1beta1.OCMLogForwarderStatus{
+		Created:                    status.Created,
+		DependenciesSatisfied:      status.DependenciesSatisfied,
+		Resources:                  status.Resources,
+		SecretsValid:               status.SecretsValid,
+		ElasticSearchProvisioned:   status.ElasticSearchProvisioned,
+		ResolvedImage:              status.ResolvedImage,
+		ImageResolutionFailure:     status.ImageResolutionFailure,
+		ImageResolutionFailureTime: status.ImageResolutionFailureTime,
+		ResolveImageRequest:        status.ResolveImageRequest,
+		Suspended:                  status.Suspended,
+		SuspendedReplicas:          status.SuspendedReplicas,
+	}
+
+	for _, condition := range status.Conditions {
+		hubCondition := appsv1beta1.OCMLogForwarderCondition(*condition)
+		hub.Conditions = append(hub.Conditions, &hubCondition)
+	}
+
+	for i := range status.Clusters {
+		hub.Clusters = append(hub.Clusters, appsv1beta1.OCMLogForwarderStatusCluster(status.Clusters[i]))
+	}
+
+	return hub
+}
+
+func statusFromHub(hub *appsv1beta1.OCMLogForwarderStatus) OCMLogForwarderStatus {
+	status := OCMLogForwarderStatus{
+		Created:                    hub.Created,
+		DependenciesSatisfied:      hub.DependenciesSatisfied,
+		Resources:                  hub.Resources,
+		SecretsValid:               hub.SecretsValid,
+		ElasticSearchProvisioned:   hub.ElasticSearchProvisioned,
+		ResolvedImage:              hub.ResolvedImage,
+		ImageResolutionFailure:     hub.ImageResolutionFailure,
+		ImageResolutionFailureTime: hub.ImageResolutionFailureTime,
+		ResolveImageRequest:        hub.ResolveImageRequest,
+		Suspended:                  hub.Suspended,
+		SuspendedReplicas:          hub.SuspendedReplicas,
+	}
+
+	for _, condition := range hub.Conditions {
+		spokeCondition := OCMLogForwarderCondition(*condition)
+		status.Conditions = append(status.Conditions, &spokeCondition)
+	}
+
+	for i := range hub.Clusters {
+		status.Clusters = append(status.Clusters, OCMLogForwarderStatusCluster(hub.Clusters[i]))
+	}
+
+	return status
+}
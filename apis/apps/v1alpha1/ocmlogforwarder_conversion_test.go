@@ -0,0 +1,269 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1beta1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1"
+)
+
+// defaultedBackend returns a backend of backendType with the configuration of every backend set, as it is
+// when the defaults of the CRD have been applied.
+func defaultedBackend(backendType string) OCMLogForwarderSpecBackend {
+	return OCMLogForwarderSpecBackend{
+		Type: backendType,
+		ElasticSearch: OCMLogForwarderSpecBackendElasticSearch{
+			SecretRef: "elastic-auth",
+			Url:       "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200",
+			AuthType:  ElasticSearchAuthTypeBasic,
+			Index:     "ocm_service_logs",
+			TLS: OCMLogForwarderSpecBackendElasticSearchTLS{
+				CARef: OCMLogForwarderSpecBackendElasticSearchTLSCARef{Kind: CARefKindSecret, Key: "ca.crt"},
+			},
+			DataStream: OCMLogForwarderSpecBackendElasticSearchDataStream{Namespace: "default"},
+		},
+		Splunk: OCMLogForwarderSpecBackendSplunk{SecretRef: "splunk-hec-token", SourceType: "ocm:servicelog"},
+		Loki:   OCMLogForwarderSpecBackendLoki{SecretRef: "loki-auth", AuthType: LokiAuthTypeNone},
+		Kafka:  OCMLogForwarderSpecBackendKafka{SecretRef: "kafka-auth", KeyStrategy: KafkaKeyStrategyClusterID},
+		S3:     OCMLogForwarderSpecBackendS3{SecretRef: "s3-credentials", Format: S3FormatJSONLGzip},
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		spec      OCMLogForwarderSpec
+		lossless  bool
+		backends  []string
+		types     []string
+		pollEvery time.Duration
+	}{
+		{
+			name: "single backend",
+			spec: OCMLogForwarderSpec{
+				Ocm:     OCMLogForwarderSpecOcm{SecretRef: "ocm-token", ClusterId: "abc", PollInternalMinutes: 5},
+				Backend: defaultedBackend(BackendTypeElasticSearch),
+				Version: "latest",
+			},
+			backends:  []string{appsv1beta1.DefaultBackendName},
+			types:     []string{appsv1beta1.BackendTypeElasticSearch},
+			pollEvery: 5 * time.Minute,
+		},
+		{
+			name: "single backend without the configuration of other backends",
+			spec: OCMLogForwarderSpec{
+				Ocm: OCMLogForwarderSpecOcm{ClusterId: "abc", PollInternalMinutes: 10},
+				Backend: OCMLogForwarderSpecBackend{
+					Type:   BackendTypeSplunk,
+					Splunk: OCMLogForwarderSpecBackendSplunk{Url: "https://splunk.example.com:8088"},
+				},
+			},
+			lossless:  true,
+			backends:  []string{appsv1beta1.DefaultBackendName},
+			types:     []string{appsv1beta1.BackendTypeSplunk},
+			pollEvery: 10 * time.Minute,
+		},
+		{
+			name: "named backends and clusters",
+			spec: OCMLogForwarderSpec{
+				Ocm: OCMLogForwarderSpecOcm{
+					Clusters: []OCMLogForwarderSpecOcmCluster{
+						{ClusterId: "abc", Index: "abc"},
+						{ClusterId: "def", Labels: map[string]string{"env": "prod"}},
+					},
+					PollInternalMinutes: 1,
+				},
+				Backend: defaultedBackend(BackendTypeElasticSearch),
+				Backends: []OCMLogForwarderSpecNamedBackend{
+					{Name: "default", OCMLogForwarderSpecBackend: defaultedBackend(BackendTypeLoki)},
+					{Name: "archive", OCMLogForwarderSpecBackend: defaultedBackend(BackendTypeS3)},
+				},
+				Filter:  OCMLogForwarderSpecFilter{IncludeServiceNames: []string{"a"}, MaxAgeMinutes: 60},
+				Suspend: true,
+			},
+			backends:  []string{"default", "archive"},
+			types:     []string{appsv1beta1.BackendTypeLoki, appsv1beta1.BackendTypeS3},
+			pollEvery: time.Minute,
+		},
+		{
+			name: "single named backend called default",
+			spec: OCMLogForwarderSpec{
+				Backends: []OCMLogForwarderSpecNamedBackend{
+					{Name: "default", OCMLogForwarderSpecBackend: OCMLogForwarderSpecBackend{
+						Type:  BackendTypeKafka,
+						Kafka: OCMLogForwarderSpecBackendKafka{BootstrapServers: []string{"kafka:9092"}},
+					}},
+				},
+			},
+			backends: []string{"default"},
+			types:    []string{appsv1beta1.BackendTypeKafka},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			original := &OCMLogForwarder{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Labels: map[string]string{"a": "b"}},
+				Spec:       tt.spec,
+				Status: OCMLogForwarderStatus{
+					Created:           true,
+					Conditions:        []*OCMLogForwarderCondition{NewCondition(&status.PhaseCondition{Phase: "Complete", State: status.PhaseStateComplete})},
+					Clusters:          []OCMLogForwarderStatusCluster{{ClusterId: "abc", Deployment: "test", Polling: true}},
+					Suspended:         tt.spec.Suspend,
+					SuspendedReplicas: map[string]int32{"test": 2},
+				},
+			}
+
+			hub := &appsv1beta1.OCMLogForwarder{}
+			if err := original.DeepCopy().ConvertTo(hub); err != nil {
+				t.Fatalf("unexpected error converting to hub: %v", err)
+			}
+
+			if _, ok := hub.Annotations[ConversionDataAnnotation]; ok == tt.lossless {
+				t.Errorf("expected conversion data annotation [%t]; found [%t]", !tt.lossless, ok)
+			}
+
+			if len(hub.Spec.Backends) != len(tt.backends) {
+				t.Fatalf("expected %d backends; found %d", len(tt.backends), len(hub.Spec.Backends))
+			}
+
+			for i := range hub.Spec.Backends {
+				if hub.Spec.Backends[i].Name != tt.backends[i] || hub.Spec.Backends[i].Type != tt.types[i] {
+					t.Errorf("expected backend [%s] of type [%s]; found backend [%s] of type [%s]",
+						tt.backends[i], tt.types[i], hub.Spec.Backends[i].Name, hub.Spec.Backends[i].Type)
+				}
+			}
+
+			if hub.Spec.Ocm.PollInterval.Duration != tt.pollEvery {
+				t.Errorf("expected poll interval [%s]; found [%s]", tt.pollEvery, hub.Spec.Ocm.PollInterval.Duration)
+			}
+
+			converted := &OCMLogForwarder{}
+			if err := converted.ConvertFrom(hub); err != nil {
+				t.Fatalf("unexpected error converting from hub: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(original, converted) {
+				t.Errorf("expected round trip to return the original object\noriginal: %+v\nconverted: %+v", original, converted)
+			}
+		})
+	}
+}
+
+func TestConvertHubRoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		spec     appsv1beta1.OCMLogForwarderSpec
+		lossless bool
+		minutes  int
+	}{
+		{
+			name: "single backend called default",
+			spec: appsv1beta1.OCMLogForwarderSpec{
+				Ocm: appsv1beta1.OCMLogForwarderSpecOcm{ClusterId: "abc", PollInterval: metav1.Duration{Duration: time.Hour}},
+				Backends: []appsv1beta1.OCMLogForwarderSpecBackend{
+					{
+						Name:          appsv1beta1.DefaultBackendName,
+						Type:          appsv1beta1.BackendTypeElasticSearch,
+						ElasticSearch: &appsv1beta1.OCMLogForwarderSpecBackendElasticSearch{Index: "ocm"},
+					},
+				},
+			},
+			lossless: true,
+			minutes:  60,
+		},
+		{
+			name: "poll interval which is not whole minutes",
+			spec: appsv1beta1.OCMLogForwarderSpec{
+				Ocm: appsv1beta1.OCMLogForwarderSpecOcm{ClusterId: "abc", PollInterval: metav1.Duration{Duration: 90 * time.Second}},
+				Backends: []appsv1beta1.OCMLogForwarderSpecBackend{
+					{Name: "splunk", Type: appsv1beta1.BackendTypeSplunk, Splunk: &appsv1beta1.OCMLogForwarderSpecBackendSplunk{}},
+					{Name: "s3", Type: appsv1beta1.BackendTypeS3, S3: &appsv1beta1.OCMLogForwarderSpecBackendS3{Bucket: "logs"}},
+				},
+			},
+			minutes: 2,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			original := &appsv1beta1.OCMLogForwarder{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec:       tt.spec,
+			}
+
+			spoke := &OCMLogForwarder{}
+			if err := spoke.ConvertFrom(original.DeepCopy()); err != nil {
+				t.Fatalf("unexpected error converting from hub: %v", err)
+			}
+
+			if _, ok := spoke.Annotations[ConversionDataAnnotation]; ok == tt.lossless {
+				t.Errorf("expected conversion data annotation [%t]; found [%t]", !tt.lossless, ok)
+			}
+
+			if spoke.Spec.Ocm.PollInternalMinutes != tt.minutes {
+				t.Errorf("expected poll interval of [%d] minutes; found [%d]", tt.minutes, spoke.Spec.Ocm.PollInternalMinutes)
+			}
+
+			converted := &appsv1beta1.OCMLogForwarder{}
+			if err := spoke.ConvertTo(converted); err != nil {
+				t.Fatalf("unexpected error converting to hub: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(original, converted) {
+				t.Errorf("expected round trip to return the original object\noriginal: %+v\nconverted: %+v", original, converted)
+			}
+		})
+	}
+}
+
+func TestConvertFromChangedHub(t *testing.T) {
+	original := &OCMLogForwarder{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: OCMLogForwarderSpec{
+			Ocm:     OCMLogForwarderSpecOcm{ClusterId: "abc", PollInternalMinutes: 5},
+			Backend: defaultedBackend(BackendTypeElasticSearch),
+		},
+	}
+
+	hub := &appsv1beta1.OCMLogForwarder{}
+	if err := original.ConvertTo(hub); err != nil {
+		t.Fatalf("unexpected error converting to hub: %v", err)
+	}
+
+	// change the backend using the hub version, which should not be overwritten by the stored spec.
+	hub.Spec.Backends[0].ElasticSearch.Index = "changed"
+
+	converted := &OCMLogForwarder{}
+	if err := converted.ConvertFrom(hub); err != nil {
+		t.Fatalf("unexpected error converting from hub: %v", err)
+	}
+
+	if converted.Spec.Backend.ElasticSearch.Index != "changed" {
+		t.Errorf("expected index [changed]; found [%s]", converted.Spec.Backend.ElasticSearch.Index)
+	}
+
+	if converted.Spec.Backend.Splunk.SecretRef != "" {
+		t.Errorf("expected configuration of unselected backends to be dropped; found %+v", converted.Spec.Backend.Splunk)
+	}
+}
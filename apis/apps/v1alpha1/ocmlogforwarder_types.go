@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 	"github.com/nukleros/operator-builder-tools/pkg/status"
@@ -27,6 +29,87 @@ import (
 
 var ErrUnableToConvertOCMLogForwarder = errors.New("unable to convert to OCMLogForwarder")
 
+// backend types which are supported by the log forwarder.
+const (
+	BackendTypeElasticSearch = "elasticsearch"
+	BackendTypeSplunk        = "splunk"
+	BackendTypeLoki          = "loki"
+	BackendTypeKafka         = "kafka"
+	BackendTypeS3            = "s3"
+)
+
+// defaults of the OCM configuration, which match the defaults of the custom resource definition.
+const (
+	DefaultOCMSecretRef           = "ocm-token"
+	DefaultOCMPollIntervalMinutes = 5
+)
+
+// defaults of the elasticsearch backend, which match the defaults of the custom resource definition.
+const (
+	DefaultElasticSearchURL       = "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200"
+	DefaultElasticSearchSecretRef = "elastic-auth"
+	DefaultElasticSearchIndex     = "ocm_service_logs"
+)
+
+// authentication types which are supported by the elasticsearch backend.
+const (
+	ElasticSearchAuthTypeBasic  = "basic"
+	ElasticSearchAuthTypeAPIKey = "apikey"
+)
+
+// secret keys which hold an elasticsearch api key when using the 'apikey' authentication type.  The key names
+// match the fields which are returned by the elasticsearch create api key api.
+const (
+	ElasticSearchAPIKeyEncodedKey = "encoded"
+	ElasticSearchAPIKeyIDKey      = "id"
+	ElasticSearchAPIKeyKey        = "api_key"
+)
+
+// kinds of objects which may contain the certificate authority bundle for the elasticsearch backend.
+const (
+	CARefKindSecret    = "Secret"
+	CARefKindConfigMap = "ConfigMap"
+)
+
+// authentication types which are supported for OCM.
+const (
+	OCMAuthTypeOfflineToken   = "offlineToken"
+	OCMAuthTypeServiceAccount = "serviceAccount"
+)
+
+// keys of the OCM secret when using the 'serviceAccount' authentication type.
+const (
+	OCMClientIDKey     = "client_id"
+	OCMClientSecretKey = "client_secret"
+)
+
+// authentication types which are supported by the loki backend.
+const (
+	LokiAuthTypeNone   = "none"
+	LokiAuthTypeBasic  = "basic"
+	LokiAuthTypeBearer = "bearer"
+)
+
+// authentication types which are supported by the kafka backend.
+const (
+	KafkaAuthTypeNone        = "none"
+	KafkaAuthTypeScramSHA256 = "scram-sha-256"
+	KafkaAuthTypeScramSHA512 = "scram-sha-512"
+	KafkaAuthTypeMTLS        = "mtls"
+)
+
+// record key strategies which are supported by the kafka backend.
+const (
+	KafkaKeyStrategyClusterID = "clusterId"
+	KafkaKeyStrategyLogID     = "logId"
+)
+
+// object formats which are supported by the s3 backend.
+const (
+	S3FormatJSONL     = "jsonl"
+	S3FormatJSONLGzip = "jsonl-gzip"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -38,105 +121,835 @@ type OCMLogForwarderSpec struct {
 	// +kubebuilder:validation:Optional
 	Ocm OCMLogForwarderSpecOcm `json:"ocm,omitempty"`
 
+	// +kubebuilder:default={type: "elasticsearch"}
 	// +kubebuilder:validation:Optional
 	Backend OCMLogForwarderSpecBackend `json:"backend,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	//  Backends where logs are sent and stored.  Each service log is sent to every backend in the list.  When
+	//  set, the .spec.backend field is ignored.
+	//
+	Backends []OCMLogForwarderSpecNamedBackend `json:"backends,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  HTTP(S) proxy configuration for the forwarder, for clusters which egress through a proxy.
+	//
+	Proxy OCMLogForwarderSpecProxy `json:"proxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Filter which selects the service logs that are forwarded to the backends.  When unset, all service
+	//  logs are forwarded.
+	//
+	Filter OCMLogForwarderSpecFilter `json:"filter,omitempty"`
+
 	// +kubebuilder:default="latest"
 	// +kubebuilder:validation:Optional
 	// (Default: "latest")
 	//  OCM Log Forwarder version to use.  Any of the tags from the ocm-log-forwarder GitHub
-	//  repo are supported here.
+	//  repo are supported here, as is an image digest such as 'sha256:...'.  A tag is resolved to a
+	//  digest when the forwarder is deployed, so that a moving tag such as 'latest' is pinned.
 	//
 	Version string `json:"version,omitempty"`
 
+	// +kubebuilder:default="ghcr.io/scottd018/ocm-log-forwarder"
+	// +kubebuilder:validation:Optional
+	// (Default: "ghcr.io/scottd018/ocm-log-forwarder")
+	//  Image repository of the OCM Log Forwarder, without a tag.  The requested version is resolved to a
+	//  digest from the registry of this repository, so that every forwarder runs the same build.  Set this
+	//  to use a mirror of the image in another registry.
+	//
+	Image string `json:"image,omitempty"`
+
 	// +kubebuilder:default=false
 	// +kubebuilder:validation:Optional
 	// (Default: false)
 	//  Enable debug logging on the log forwarder.
 	//
-	Debug bool `json:"debug,omitempty"`
+	Debug bool `json:"debug,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Suspend forwarding without deleting this resource.  The forwarder deployments are scaled to zero
+	//  while their RBAC is left intact, and their previous replica counts are restored when forwarding
+	//  is resumed.
+	//
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+type OCMLogForwarderSpecOcm struct {
+	// +kubebuilder:default="ocm-token"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm-token")
+	//  The secret should contain the OCM credentials.  See .spec.ocm.authType for more information on
+	//  secret requirements.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:default="offlineToken"
+	// +kubebuilder:validation:Optional
+	// (Default: "offlineToken")
+	//  +kubebuilder:validation:Enum=offlineToken;serviceAccount
+	//  OCM authentication type to use.
+	//
+	//  * 'offlineToken': The secret from .spec.ocm.secretRef should contain the OCM JSON token obtained
+	//  from OpenShift Cluster Manager.  It should have a single key/value pair with the form of
+	//  clusterId=ocmTokenJson.  The clusterId should match the .spec.ocm.clusterId field, while the
+	//  ocmTokenJson value should be a string form of the token obtained from OCM.
+	//
+	//  * 'serviceAccount': The secret from .spec.ocm.secretRef should contain the client id and client
+	//  secret of a Red Hat service account at keys 'client_id' and 'client_secret'.
+	//
+	AuthType string `json:"authType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Cluster ID of the cluster to forward logs from.  This Cluster ID can be found in the OCM Console
+	//  as part of the URL when selecting the cluster.  It shows up in a form such as
+	//  '22tgckqk9c2ff3jd8ve62p0i2st14vrq'.  Either this field or .spec.ocm.clusters must be set.
+	//
+	ClusterId string `json:"clusterId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=clusterId
+	//  Clusters to forward logs from.  A forwarder is run for each cluster in the list.  Either this
+	//  field or .spec.ocm.clusterId must be set.
+	//
+	Clusters []OCMLogForwarderSpecOcmCluster `json:"clusters,omitempty"`
+
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Optional
+	// (Default: 5)
+	//  +kubebuilder:validation:Minimum=1
+	//  +kubebuilder:validation:Maximum=1440
+	//  How frequently, in minutes, the controller will poll the OpenShift Cluster Manager console for service logs.  Must
+	//  be in the range of 1 minute to 1440 minutes (1 day).
+	//
+	PollInternalMinutes int `json:"pollInternalMinutes,omitempty"`
+
+	// +kubebuilder:default="https://api.openshift.com"
+	// +kubebuilder:validation:Optional
+	// (Default: "https://api.openshift.com")
+	//  URL of the OpenShift Cluster Manager API from which service logs are retrieved.  Set this to use
+	//  a non-default OCM environment, such as 'https://api.stage.openshift.com' for staging or the API
+	//  of a FedRAMP environment.
+	//
+	ApiURL string `json:"apiURL,omitempty"`
+
+	// +kubebuilder:default="https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+	// +kubebuilder:validation:Optional
+	// (Default: "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token")
+	//  URL of the SSO token endpoint which is used to exchange the OCM credentials for an access token.
+	//  Set this when the OCM environment from .spec.ocm.apiURL uses a different SSO.
+	//
+	TokenURL string `json:"tokenURL,omitempty"`
+}
+
+// default OCM endpoints, which are those of the commercial OpenShift Cluster Manager.
+const (
+	OCMDefaultAPIURL   = "https://api.openshift.com"
+	OCMDefaultTokenURL = "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+)
+
+// DefaultImage is the image repository of the OCM Log Forwarder.
+const DefaultImage = "ghcr.io/scottd018/ocm-log-forwarder"
+
+// GetImage returns the image repository of the forwarder, which defaults to the published image.
+func (component *OCMLogForwarder) GetImage() string {
+	if component.Spec.Image == "" {
+		return DefaultImage
+	}
+
+	return component.Spec.Image
+}
+
+// GetVersion returns the requested version of the forwarder, which defaults to the latest version.
+func (component *OCMLogForwarder) GetVersion() string {
+	if component.Spec.Version == "" {
+		return "latest"
+	}
+
+	return component.Spec.Version
+}
+
+// VersionIsDigest returns whether the requested version of the forwarder is an image digest rather
+// than a tag, in which case the version does not need to be resolved.
+func (component *OCMLogForwarder) VersionIsDigest() bool {
+	return strings.HasPrefix(component.GetVersion(), "sha256:")
+}
+
+// GetRequestedImage returns the image reference of the requested version of the forwarder.
+func (component *OCMLogForwarder) GetRequestedImage() string {
+	if component.VersionIsDigest() {
+		return component.GetImage() + "@" + component.GetVersion()
+	}
+
+	return component.GetImage() + ":" + component.GetVersion()
+}
+
+// GetDeploymentImage returns the image reference which the forwarder deployment runs.  This is the
+// resolved image when it was resolved from the requested image, so that the deployment is only rolled
+// to a new image once the requested image has been resolved again.
+func (component *OCMLogForwarder) GetDeploymentImage() string {
+	requested := component.GetRequestedImage()
+
+	if resolved := component.GetResolvedImageStatus(); strings.HasPrefix(resolved, requested+"@") {
+		return resolved
+	}
+
+	return requested
+}
+
+// DefaultReplicas is the replica count which a forwarder deployment is scaled back to when forwarding is
+// resumed, when the deployment was already scaled to zero when forwarding was suspended.
+const DefaultReplicas int32 = 1
+
+// GetDeploymentReplicas returns the replica count of the forwarder deployments, which is zero while forwarding
+// is suspended.  Otherwise it is nil, so that the replica count of the forwarder deployments is not managed and
+// they may be scaled, such as with 'kubectl scale'.
+func (component *OCMLogForwarder) GetDeploymentReplicas() *int32 {
+	if !component.Spec.Suspend {
+		return nil
+	}
+
+	replicas := int32(0)
+
+	return &replicas
+}
+
+// GetResumedReplicas returns the replica count which a forwarder deployment is scaled back to when forwarding
+// is resumed, which is the replica count of the deployment when forwarding was suspended.
+func (component *OCMLogForwarder) GetResumedReplicas(deployment string) int32 {
+	if replicas, ok := component.GetSuspendedReplicasStatus()[deployment]; ok && replicas > 0 {
+		return replicas
+	}
+
+	return DefaultReplicas
+}
+
+// GetAPIURL returns the URL of the OCM API, which defaults to the commercial OCM API.
+func (ocm *OCMLogForwarderSpecOcm) GetAPIURL() string {
+	if ocm.ApiURL == "" {
+		return OCMDefaultAPIURL
+	}
+
+	return ocm.ApiURL
+}
+
+// GetTokenURL returns the URL of the SSO token endpoint, which defaults to the SSO of the commercial OCM.
+func (ocm *OCMLogForwarderSpecOcm) GetTokenURL() string {
+	if ocm.TokenURL == "" {
+		return OCMDefaultTokenURL
+	}
+
+	return ocm.TokenURL
+}
+
+type OCMLogForwarderSpecFilter struct {
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:Enum=Debug;Info;Warning;Error;Fatal
+	//  Minimum severity of the service logs which are forwarded.  For example, 'Warning' forwards service
+	//  logs with a severity of 'Warning', 'Error' or 'Fatal'.
+	//
+	MinSeverity string `json:"minSeverity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Service names of the service logs which are forwarded.  When set, service logs from any other
+	//  service are not forwarded.
+	//
+	IncludeServiceNames []string `json:"includeServiceNames,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Service names of the service logs which are not forwarded.
+	//
+	ExcludeServiceNames []string `json:"excludeServiceNames,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Regular expression, in Go RE2 syntax, which the summary of a service log must match to be forwarded.
+	//
+	SummaryRegex string `json:"summaryRegex,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:Minimum=1
+	//  Maximum age, in minutes, of the service logs which are forwarded.  Older service logs, such as those
+	//  found when the forwarder first starts, are not forwarded.
+	//
+	MaxAgeMinutes int `json:"maxAgeMinutes,omitempty"`
+}
+
+// severities of OCM service logs, in increasing order.
+const (
+	SeverityDebug   = "Debug"
+	SeverityInfo    = "Info"
+	SeverityWarning = "Warning"
+	SeverityError   = "Error"
+	SeverityFatal   = "Fatal"
+)
+
+type OCMLogForwarderSpecProxy struct {
+	// +kubebuilder:validation:Optional
+	//  URL of the proxy for http requests, which is passed to the forwarder as HTTP_PROXY.
+	//
+	HttpProxy string `json:"httpProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  URL of the proxy for https requests, which is passed to the forwarder as HTTPS_PROXY.
+	//
+	HttpsProxy string `json:"httpsProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Comma-separated list of hosts, domains and CIDRs which bypass the proxy, which is passed to the
+	//  forwarder as NO_PROXY.
+	//
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Inherit the proxy configuration from the cluster-wide config.openshift.io/v1 Proxy object named
+	//  'cluster' when none of httpProxy, httpsProxy and noProxy are set.  Only applies to OpenShift.
+	//
+	InheritClusterProxy bool `json:"inheritClusterProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Inject the trusted CA bundle of the cluster, which includes the certificate authority of the proxy,
+	//  into the forwarder using a ConfigMap with the 'config.openshift.io/inject-trusted-cabundle' label.
+	//  Only applies to OpenShift.
+	//
+	InjectTrustedCABundle bool `json:"injectTrustedCABundle,omitempty"`
+}
+
+// IsSet returns whether any of the proxy values are set.
+func (proxy *OCMLogForwarderSpecProxy) IsSet() bool {
+	return proxy.HttpProxy != "" || proxy.HttpsProxy != "" || proxy.NoProxy != ""
+}
+
+// ClusterProxyGroupVersionKind is the group, version and kind of the cluster-wide proxy configuration of
+// OpenShift.
+var ClusterProxyGroupVersionKind = schema.GroupVersionKind{
+	Group:   "config.openshift.io",
+	Version: "v1",
+	Kind:    "Proxy",
+}
+
+// ClusterProxyName is the name of the cluster-wide proxy configuration of OpenShift.
+const ClusterProxyName = "cluster"
+
+type OCMLogForwarderSpecOcmCluster struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	//  Cluster ID of the cluster to forward logs from.  The Cluster ID is used as the value of a label, and
+	//  in the name of the deployment which forwards logs for the cluster, which is the name of this
+	//  resource followed by '-' and the Cluster ID.
+	//
+	ClusterId string `json:"clusterId"`
+
+	// +kubebuilder:validation:Optional
+	//  The secret containing the OCM credentials for this cluster.  If unset, the secret from
+	//  .spec.ocm.secretRef is used.  When using the 'offlineToken' authentication type, a secret which
+	//  is shared by multiple clusters should have a key/value pair of clusterId=ocmTokenJson for each
+	//  of those clusters.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Index which overrides the backend index for the service logs of this cluster.  Applies to the
+	//  'elasticsearch' and 'splunk' backends.  For the 'elasticsearch' backend, this also overrides the
+	//  indexPattern and ilm.rolloverAlias of the backend.
+	//
+	Index string `json:"index,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Labels which are merged over the backend stream labels for the service logs of this cluster.
+	//  Applies to the 'loki' backend.
+	//
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type OCMLogForwarderSpecBackend struct {
+	// +kubebuilder:default={authType: "basic"}
+	// +kubebuilder:validation:Optional
+	ElasticSearch OCMLogForwarderSpecBackendElasticSearch `json:"elasticSearch,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Splunk OCMLogForwarderSpecBackendSplunk `json:"splunk,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Loki OCMLogForwarderSpecBackendLoki `json:"loki,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Kafka OCMLogForwarderSpecBackendKafka `json:"kafka,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	S3 OCMLogForwarderSpecBackendS3 `json:"s3,omitempty"`
+
+	// +kubebuilder:default="elasticsearch"
+	// +kubebuilder:validation:Optional
+	// (Default: "elasticsearch")
+	//  +kubebuilder:validation:Enum=elasticsearch;splunk;loki;kafka;s3
+	//  Backend type where logs are sent and stored.  Requires the configuration for the matching backend
+	//  to be set, such as backend.elasticSearch.url for 'elasticsearch', backend.splunk.url for 'splunk',
+	//  backend.loki.url for 'loki', backend.kafka.bootstrapServers for 'kafka' or backend.s3.bucket for 's3'.
+	//
+	Type string `json:"type,omitempty"`
+}
+
+type OCMLogForwarderSpecNamedBackend struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	//  Unique name of the backend.  Must be 32 characters or less and consist of lower case alphanumeric
+	//  characters or '-'.
+	//
+	Name string `json:"name"`
+
+	OCMLogForwarderSpecBackend `json:",inline"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearch struct {
+	// +kubebuilder:default="elastic-auth"
+	// +kubebuilder:validation:Optional
+	// (Default: "elastic-auth")
+	//  The secret should contain the authentication information for the ElasticSearch connection.  See
+	//  .spec.backend.elasticSearch.authType for more information on secret requirements.  This secret
+	//  should exist in the same namespace as the OCMLogForwarder resource.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:default="https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200"
+	// +kubebuilder:validation:Optional
+	// (Default: "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200")
+	//  URL to which to ship logs when using the 'elasticsearch' as a backend in the .spec.backend.type
+	//  field of this custom resource.
+	//
+	Url string `json:"url,omitempty"`
+
+	// +kubebuilder:default="basic"
+	// +kubebuilder:validation:Optional
+	// (Default: "basic")
+	//  +kubebuilder:validation:Enum=basic;apikey
+	//  ElasticSearch authentication type to use.
+	//
+	//  * 'basic': For 'basic' authentication, the secret from .spec.backend.elasticSearch.secretRef should contain the
+	//  basic authentication information for the ElasticSearch connection containing only a single key/value pair with
+	//  the key as the username and the value as the password.
+	//
+	//  * 'apikey': For 'apikey' authentication, the secret from .spec.backend.elasticSearch.secretRef should contain
+	//  an ElasticSearch API key, either as the base64 encoded API key at key 'encoded', or as the API key id and
+	//  API key at keys 'id' and 'api_key'.  The key names match the fields returned by the ElasticSearch create
+	//  API key API.
+	//
+	AuthType string `json:"authType,omitempty"`
+
+	// +kubebuilder:default="ocm_service_logs"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm_service_logs")
+	//  +kubebuilder:validation:MaxLength=128
+	//  Index name in ElasticSearch where service logs are sent.  Index name must be 128 characters or less.
+	//
+	Index string `json:"index,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:MaxLength=128
+	//  Index name pattern in ElasticSearch where service logs are sent, which takes precedence over
+	//  .spec.backend.elasticSearch.index.  The pattern may contain date math within braces, which is
+	//  resolved by the forwarder in UTC when each service log is sent, such as 'ocm_service_logs-{yyyy.MM.dd}'.
+	//  Supported date math tokens are 'yyyy', 'yy', 'MM', 'dd' and 'HH', which may be separated by '.', '-'
+	//  or '_'.
+	//
+	IndexPattern string `json:"indexPattern,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Index lifecycle management configuration, so that the retention of service logs may be managed by
+	//  ElasticSearch lifecycle policies.
+	//
+	ILM OCMLogForwarderSpecBackendElasticSearchILM `json:"ilm,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Data stream configuration.  When enabled, service logs are written to an ElasticSearch data stream
+	//  instead of a plain index.
+	//
+	DataStream OCMLogForwarderSpecBackendElasticSearchDataStream `json:"dataStream,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Whether the operator creates and updates an index template, with explicit mappings for the fields
+	//  of OCM service logs, for the indices to which service logs are sent.  The index template is
+	//  provisioned using the ElasticSearch REST API prior to creating the forwarder.
+	//
+	ManageIndexTemplate bool `json:"manageIndexTemplate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:Pattern=`^[1-9][0-9]*(d|h|m|s)$`
+	//  Duration for which service logs are retained, such as '30d'.  When set, the operator creates and
+	//  updates an index lifecycle policy, named by .spec.backend.elasticSearch.ilm.policyName or generated
+	//  from the name of this resource, which deletes indices once they reach this age.  Requires
+	//  .spec.backend.elasticSearch.manageIndexTemplate or .spec.backend.elasticSearch.dataStream.enabled.
+	//
+	Retention string `json:"retention,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	TLS OCMLogForwarderSpecBackendElasticSearchTLS `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Reference to an ECK managed Elasticsearch.  When set, the url, credentials and certificate
+	//  authority are resolved from the referenced Elasticsearch, and the url, secretRef, authType and
+	//  tls.caRef fields are ignored.
+	//
+	ElasticsearchRef OCMLogForwarderSpecBackendElasticSearchRef `json:"elasticsearchRef,omitempty"`
+}
+
+// GetIndexTemplatePattern returns the index pattern which matches the indices that service logs are sent to
+// by the elasticsearch backend, which is used for the index template that is managed by the operator.
+func (elasticSearch *OCMLogForwarderSpecBackendElasticSearch) GetIndexTemplatePattern() string {
+	switch {
+	case elasticSearch.DataStream.Enabled:
+		return elasticSearch.DataStream.GetName()
+	case elasticSearch.ILM.RolloverAlias != "":
+		return elasticSearch.ILM.RolloverAlias + "-*"
+	case elasticSearch.IndexPattern != "":
+		return elasticSearchDateMath.ReplaceAllString(elasticSearch.IndexPattern, "*")
+	default:
+		return elasticSearch.Index
+	}
+}
+
+type OCMLogForwarderSpecBackendElasticSearchDataStream struct {
+	// +kubebuilder:validation:Optional
+	//  Whether service logs are written to the data stream.  When enabled, the index template which enables
+	//  the data stream is always managed by the operator, and .spec.backend.elasticSearch.index is ignored.
+	//  Cannot be used with .spec.backend.elasticSearch.indexPattern or .spec.backend.elasticSearch.ilm.rolloverAlias.
+	//
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:default="default"
+	// +kubebuilder:validation:Optional
+	// (Default: "default")
+	//  Namespace of the data stream, which follows the ElasticSearch data stream naming scheme.  Service
+	//  logs are written to the data stream named 'logs-ocm.service-<namespace>'.  The namespace must be
+	//  lowercase and must not contain '-'.
+	//
+	Namespace string `json:"namespace,omitempty"`
+}
+
+const (
+	// ElasticSearchDataStreamPrefix is the prefix, made up of the type and dataset, of the data stream to which
+	// service logs are written.
+	ElasticSearchDataStreamPrefix = "logs-ocm.service-"
+
+	// ElasticSearchDataStreamDefaultNamespace is the default namespace of the data stream.
+	ElasticSearchDataStreamDefaultNamespace = "default"
+)
+
+// GetName returns the name of the data stream to which service logs are written.
+func (dataStream OCMLogForwarderSpecBackendElasticSearchDataStream) GetName() string {
+	if dataStream.Namespace == "" {
+		return ElasticSearchDataStreamPrefix + ElasticSearchDataStreamDefaultNamespace
+	}
+
+	return ElasticSearchDataStreamPrefix + dataStream.Namespace
+}
+
+type OCMLogForwarderSpecBackendElasticSearchILM struct {
+	// +kubebuilder:validation:Optional
+	//  Name of the ElasticSearch index lifecycle policy which is applied to the indices that the forwarder
+	//  creates.  Required when .spec.backend.elasticSearch.ilm.rolloverAlias is set.
+	//
+	PolicyName string `json:"policyName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:MaxLength=128
+	//  Rollover alias to which service logs are sent.  When set, the forwarder writes to the alias, which
+	//  is rolled over to a new index by the index lifecycle policy, and .spec.backend.elasticSearch.index
+	//  is ignored.  Cannot be used with .spec.backend.elasticSearch.indexPattern.
+	//
+	RolloverAlias string `json:"rolloverAlias,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearchRef struct {
+	// +kubebuilder:validation:Optional
+	//  Name of the elasticsearch.k8s.elastic.co Elasticsearch object.
+	//
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Namespace of the elasticsearch.k8s.elastic.co Elasticsearch object.  If unset, the namespace of the
+	//  OCMLogForwarder resource is used.  An Elasticsearch in another namespace must list the namespace of
+	//  the OCMLogForwarder resource in its apps.dustinscott.io/allowed-namespaces annotation.
+	//
+	Namespace string `json:"namespace,omitempty"`
+}
+
+const (
+	// ElasticsearchRefUserKey is the key of the password of the elastic user in the secret which is managed
+	// by ECK.  The key is also the username.
+	ElasticsearchRefUserKey = "elastic"
+
+	// ElasticsearchRefCAKey is the key of the certificate authority in the secret which is managed by ECK.
+	ElasticsearchRefCAKey = "ca.crt"
+)
+
+// UserSecretName returns the name of the secret, which is managed by ECK, that contains the password of the
+// elastic user of the referenced Elasticsearch.
+func (ref OCMLogForwarderSpecBackendElasticSearchRef) UserSecretName() string {
+	return ref.Name + "-es-elastic-user"
+}
+
+// CASecretName returns the name of the secret, which is managed by ECK, that contains the certificate
+// authority of the http layer of the referenced Elasticsearch.
+func (ref OCMLogForwarderSpecBackendElasticSearchRef) CASecretName() string {
+	return ref.Name + "-es-http-certs-public"
+}
+
+type OCMLogForwarderSpecBackendElasticSearchTLS struct {
+	// +kubebuilder:validation:Optional
+	//  Certificate authority bundle used to verify the certificate presented by ElasticSearch, such as the
+	//  CA of an ECK managed ElasticSearch.  If unset, the system trust store is used.
+	//
+	CARef OCMLogForwarderSpecBackendElasticSearchTLSCARef `json:"caRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  The secret should contain the client certificate and key, at keys 'tls.crt' and 'tls.key', which are
+	//  presented to ElasticSearch for mutual TLS.  This secret should exist in the same namespace as the
+	//  OCMLogForwarder resource.
+	//
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Skip verification of the certificate presented by ElasticSearch.  This should only be used for testing.
+	//
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearchTLSCARef struct {
+	// +kubebuilder:default="Secret"
+	// +kubebuilder:validation:Optional
+	// (Default: "Secret")
+	//  +kubebuilder:validation:Enum=Secret;ConfigMap
+	//  Kind of the object which contains the certificate authority bundle.
+	//
+	Kind string `json:"kind,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Name of the object, in the same namespace as the OCMLogForwarder resource, which contains the
+	//  certificate authority bundle.
+	//
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:default="ca.crt"
+	// +kubebuilder:validation:Optional
+	// (Default: "ca.crt")
+	//  Key of the object which contains the PEM encoded certificate authority bundle.
+	//
+	Key string `json:"key,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendSplunk struct {
+	// +kubebuilder:default="splunk-hec-token"
+	// +kubebuilder:validation:Optional
+	// (Default: "splunk-hec-token")
+	//  The secret should contain the Splunk HTTP Event Collector token used to authenticate to the Splunk
+	//  HTTP Event Collector.  It should have a single key/value pair with the form of token=hecToken.  This
+	//  secret should exist in the same namespace as the OCMLogForwarder resource.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  URL of the Splunk HTTP Event Collector to which to ship logs when using 'splunk' as a backend in the
+	//  .spec.backend.type field of this custom resource.  It shows up in a form such as
+	//  'https://splunk.example.com:8088'.
+	//
+	Url string `json:"url,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Index in Splunk where service logs are sent.  If unset, the default index of the HTTP Event Collector
+	//  token is used.
+	//
+	Index string `json:"index,omitempty"`
+
+	// +kubebuilder:default="ocm:servicelog"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm:servicelog")
+	//  Source type which is set on the events that are sent to Splunk.
+	//
+	SourceType string `json:"sourceType,omitempty"`
+
+	// +kubebuilder:default="ocm-log-forwarder"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm-log-forwarder")
+	//  Source which is set on the events that are sent to Splunk.
+	//
+	Source string `json:"source,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Skip verification of the TLS certificate presented by the Splunk HTTP Event Collector.  This should
+	//  only be used for testing.
+	//
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendLoki struct {
+	// +kubebuilder:default="loki-auth"
+	// +kubebuilder:validation:Optional
+	// (Default: "loki-auth")
+	//  The secret should contain the authentication information for the Loki connection.  See
+	//  .spec.backend.loki.authType for more information on secret requirements.  This secret
+	//  should exist in the same namespace as the OCMLogForwarder resource.  Unused when
+	//  .spec.backend.loki.authType is 'none'.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Push URL of Loki to which to ship logs when using 'loki' as a backend in the .spec.backend.type
+	//  field of this custom resource.  It shows up in a form such as
+	//  'http://loki-gateway.loki.svc.cluster.local/loki/api/v1/push'.
+	//
+	Url string `json:"url,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Tenant ID which is sent as the X-Scope-OrgID header when Loki is running in multi-tenant mode.
+	//
+	TenantId string `json:"tenantId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Static labels which are added to the stream of each service log that is sent to Loki.  Label names
+	//  must match the regex '[a-zA-Z_][a-zA-Z0-9_]*'.
+	//
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +kubebuilder:default="none"
+	// +kubebuilder:validation:Optional
+	// (Default: "none")
+	//  +kubebuilder:validation:Enum=none;basic;bearer
+	//  Loki authentication type to use.
+	//
+	//  * 'none': No authentication is sent to Loki.
+	//
+	//  * 'basic': For 'basic' authentication, the secret from .spec.backend.loki.secretRef should contain the
+	//  basic authentication information for the Loki connection containing only a single key/value pair with
+	//  the key as the username and the value as the password.
+	//
+	//  * 'bearer': For 'bearer' authentication, the secret from .spec.backend.loki.secretRef should contain the
+	//  bearer token for the Loki connection with a single key/value pair with the form of token=bearerToken.
+	//
+	AuthType string `json:"authType,omitempty"`
 }
 
-type OCMLogForwarderSpecOcm struct {
-	// +kubebuilder:default="ocm-token"
+type OCMLogForwarderSpecBackendKafka struct {
+	// +kubebuilder:default="kafka-auth"
 	// +kubebuilder:validation:Optional
-	// (Default: "ocm-token")
-	//  The secret should contain the OCM JSON token obtained from OpenShift Cluster Manager.  It should
-	//  have a single key/value pair with the form of clusterId=ocmTokenJson.  The clusterId
-	//  should match the .spec.ocm.clusterId field, while the ocmTokenJson value should be a
-	//  string form of the token obtained from OCM.
+	// (Default: "kafka-auth")
+	//  The secret should contain the credentials for the Kafka connection.  See .spec.backend.kafka.authType
+	//  for more information on secret requirements.  This secret should exist in the same namespace as the
+	//  OCMLogForwarder resource.  Unused when .spec.backend.kafka.authType is 'none'.
 	//
 	SecretRef string `json:"secretRef,omitempty"`
 
-	// +kubebuilder:validation:Required
-	//  Cluster ID of the cluster to forward logs from.  This Cluster ID can be found in the OCM Console
-	//  as part of the URL when selecting the cluster.  It shows up in a form such as
-	//  '22tgckqk9c2ff3jd8ve62p0i2st14vrq'.
+	// +kubebuilder:validation:Optional
+	//  Kafka bootstrap servers, in host:port form, to which to produce service logs when using 'kafka' as a
+	//  backend in the .spec.backend.type field of this custom resource.
 	//
-	ClusterId string `json:"clusterId,omitempty"`
+	BootstrapServers []string `json:"bootstrapServers,omitempty"`
 
-	// +kubebuilder:default=5
 	// +kubebuilder:validation:Optional
-	// (Default: 5)
-	//  +kubebuilder:validation:Minimum=1
-	//  +kubebuilder:validation:Maximum=1440
-	//  How frequently, in minutes, the controller will poll the OpenShift Cluster Manager console for service logs.  Must
-	//  be in the range of 1 minute to 1440 minutes (1 day).
+	//  Topic to which each service log is produced as a JSON record.
 	//
-	PollInternalMinutes int `json:"pollInternalMinutes,omitempty"`
-}
+	Topic string `json:"topic,omitempty"`
 
-type OCMLogForwarderSpecBackend struct {
+	// +kubebuilder:default="clusterId"
 	// +kubebuilder:validation:Optional
-	ElasticSearch OCMLogForwarderSpecBackendElasticSearch `json:"elasticSearch,omitempty"`
+	// (Default: "clusterId")
+	//  +kubebuilder:validation:Enum=clusterId;logId
+	//  Strategy used to set the key of each produced record.  Use 'clusterId' to keep the service logs of
+	//  a cluster in a single partition or 'logId' to spread service logs across partitions.
+	//
+	KeyStrategy string `json:"keyStrategy,omitempty"`
 
-	// +kubebuilder:default="elasticsearch"
+	// +kubebuilder:default="none"
 	// +kubebuilder:validation:Optional
-	// (Default: "elasticsearch")
-	//  +kubebuilder:validation:Enum=elasticsearch
-	//  Backend type where logs are sent and stored.  Only 'elasticsearch' supported at this time.  Requires
-	//  backend.elasticSearch.url to be set.
+	// (Default: "none")
+	//  +kubebuilder:validation:Enum=none;scram-sha-256;scram-sha-512;mtls
+	//  Kafka authentication type to use.
 	//
-	Type string `json:"type,omitempty"`
+	//  * 'none': No authentication is used for the Kafka connection.
+	//
+	//  * 'scram-sha-256' and 'scram-sha-512': For SASL/SCRAM authentication, the secret from
+	//  .spec.backend.kafka.secretRef should contain only a single key/value pair with the key as the
+	//  username and the value as the password.
+	//
+	//  * 'mtls': For mutual TLS authentication, the secret from .spec.backend.kafka.secretRef should contain
+	//  the client certificate and key at keys 'tls.crt' and 'tls.key', and optionally the certificate
+	//  authority used to verify the brokers at key 'ca.crt'.
+	//
+	AuthType string `json:"authType,omitempty"`
 }
 
-type OCMLogForwarderSpecBackendElasticSearch struct {
-	// +kubebuilder:default="elastic-auth"
+type OCMLogForwarderSpecBackendS3 struct {
+	// +kubebuilder:default="s3-credentials"
 	// +kubebuilder:validation:Optional
-	// (Default: "elastic-auth")
-	//  The secret should contain the authentication information for the ElasticSearch connection.  See
-	//  .spec.backend.elasticSearch.authType for more information on secret requirements.  This secret
+	// (Default: "s3-credentials")
+	//  The secret should contain the credentials for the object storage connection with the access key id
+	//  at key 'AWS_ACCESS_KEY_ID' and the secret access key at key 'AWS_SECRET_ACCESS_KEY'.  This secret
 	//  should exist in the same namespace as the OCMLogForwarder resource.
 	//
 	SecretRef string `json:"secretRef,omitempty"`
 
-	// +kubebuilder:default="https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200"
 	// +kubebuilder:validation:Optional
-	// (Default: "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200")
-	//  URL to which to ship logs when using the 'elasticsearch' as a backend in the .spec.backend.type
+	//  Endpoint of the S3-compatible object storage, such as 'https://minio.minio.svc.cluster.local:9000'.
+	//  Leave unset to use the AWS S3 endpoint for .spec.backend.s3.region.
+	//
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Bucket in which service log archives are stored when using 's3' as a backend in the .spec.backend.type
 	//  field of this custom resource.
 	//
-	Url string `json:"url,omitempty"`
+	Bucket string `json:"bucket,omitempty"`
 
-	// +kubebuilder:default="basic"
+	// +kubebuilder:default="ocm-service-logs/{clusterId}/{year}/{month}/{day}"
 	// +kubebuilder:validation:Optional
-	// (Default: "basic")
-	//  +kubebuilder:validation:Enum=basic
-	//  ElasticSearch authentication type to use.  Only 'basic' supported at this time.
+	// (Default: "ocm-service-logs/{clusterId}/{year}/{month}/{day}")
+	//  Template of the key prefix for each archive object.  The placeholders '{clusterId}', '{year}',
+	//  '{month}' and '{day}' are replaced with the cluster ID and the UTC date at which the object is written.
 	//
-	//  * 'basic': For 'basic' authentication, the secret from .spec.backend.elasticSearch.secretRef should contain the
-	//  basic authentication information for the ElasticSearch connection containing only a single key/value pair with
-	//  the key as the username and the value as the password.
+	Prefix string `json:"prefix,omitempty"`
+
+	// +kubebuilder:default="us-east-1"
+	// +kubebuilder:validation:Optional
+	// (Default: "us-east-1")
+	//  Region of the bucket.
 	//
-	AuthType string `json:"authType,omitempty"`
+	Region string `json:"region,omitempty"`
 
-	// +kubebuilder:default="ocm_service_logs"
+	// +kubebuilder:default=false
 	// +kubebuilder:validation:Optional
-	// (Default: "ocm_service_logs")
-	//  +kubebuilder:validation:MaxLength=128
-	//  Index name in ElasticSearch where service logs are sent.  Index name must be 128 characters or less.
+	// (Default: false)
+	//  Use path-style addressing (endpoint/bucket/key) rather than virtual-hosted-style addressing
+	//  (bucket.endpoint/key).  This is commonly required by MinIO and other S3-compatible object storage.
 	//
-	Index string `json:"index,omitempty"`
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
+	// +kubebuilder:default="jsonl-gzip"
+	// +kubebuilder:validation:Optional
+	// (Default: "jsonl-gzip")
+	//  +kubebuilder:validation:Enum=jsonl;jsonl-gzip
+	//  Format of each archive object.  Use 'jsonl' for newline-delimited JSON or 'jsonl-gzip' for gzip
+	//  compressed newline-delimited JSON.
+	//
+	Format string `json:"format,omitempty"`
+
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Optional
+	// (Default: 60)
+	//  +kubebuilder:validation:Minimum=1
+	//  +kubebuilder:validation:Maximum=1440
+	//  How frequently, in minutes, buffered service logs are flushed to a new archive object.  Must be in the
+	//  range of 1 minute to 1440 minutes (1 day).
+	//
+	FlushIntervalMinutes int `json:"flushIntervalMinutes,omitempty"`
 }
 
 // OCMLogForwarderStatus defines the observed state of OCMLogForwarder.
@@ -144,10 +957,92 @@ type OCMLogForwarderStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	Created               bool                     `json:"created,omitempty"`
-	DependenciesSatisfied bool                     `json:"dependenciesSatisfied,omitempty"`
-	Conditions            []*status.PhaseCondition `json:"conditions,omitempty"`
-	Resources             []*status.ChildResource  `json:"resources,omitempty"`
+	Created               bool                        `json:"created,omitempty"`
+	DependenciesSatisfied bool                        `json:"dependenciesSatisfied,omitempty"`
+	Conditions            []*OCMLogForwarderCondition `json:"conditions,omitempty"`
+	Resources             []*status.ChildResource     `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the secrets referenced by this resource are valid.  See the SecretsValid
+	// condition for the reason that the secrets are invalid.
+	SecretsValid bool `json:"secretsValid,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the index templates and index lifecycle policies managed by this resource have been
+	// provisioned in ElasticSearch.  See the Provision-ElasticSearch phase condition for the reason that
+	// provisioning failed.
+	ElasticSearchProvisioned bool `json:"elasticSearchProvisioned,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The forwarding status of each cluster which logs are forwarded from.
+	Clusters []OCMLogForwarderStatusCluster `json:"clusters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image which the forwarder runs, which is the requested image pinned to the digest that the requested
+	// version resolved to, such as 'ghcr.io/scottd018/ocm-log-forwarder:latest@sha256:...'.  See the
+	// ImageResolved condition for the reason that the version could not be resolved.
+	ResolvedImage string `json:"resolvedImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Reason that the requested version could not be resolved when it was last resolved, in which case the
+	// forwarder runs the previously resolved image, or the requested image when it was never resolved.
+	ImageResolutionFailure string `json:"imageResolutionFailure,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Time that the requested version could not be resolved.  The requested version is not resolved again
+	// until the image resolution retry interval has passed since this time.
+	ImageResolutionFailureTime *metav1.Time `json:"imageResolutionFailureTime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Value of the apps.dustinscott.io/resolve-image annotation when the requested version was last
+	// resolved.  The requested version is resolved again when the annotation is set to another value.
+	ResolveImageRequest string `json:"resolveImageRequest,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether forwarding is suspended, in which case the forwarder deployments are scaled to zero.  See
+	// the Suspended condition for when forwarding was suspended.
+	Suspended bool `json:"suspended,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Replica count of each forwarder deployment, by deployment name, when forwarding was suspended.
+	// These replica counts are restored, and cleared, when forwarding is resumed.
+	SuspendedReplicas map[string]int32 `json:"suspendedReplicas,omitempty"`
+}
+
+// OCMLogForwarderCondition defines a phase condition of the controller, along with the type and status of a
+// standard condition, so that the condition may be waited on with 'kubectl wait --for=condition=<type>'.
+type OCMLogForwarderCondition struct {
+	status.PhaseCondition `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// Type of the condition, which is the name of the phase unless the phase sets a condition with
+	// another name, such as SecretsValid for the Validate-Secrets phase.
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Status of the condition, which is True when the phase has completed, False when the phase has
+	// failed, and Unknown otherwise.
+	Status metav1.ConditionStatus `json:"status,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Reason for the status of the condition, which is the state of the phase.
+	Reason string `json:"reason,omitempty"`
+}
+
+// OCMLogForwarderStatusCluster defines the observed forwarding state of a single cluster.
+type OCMLogForwarderStatusCluster struct {
+	// Cluster ID of the cluster which logs are forwarded from.
+	ClusterId string `json:"clusterId"`
+
+	// Name of the deployment which forwards logs for the cluster.
+	Deployment string `json:"deployment"`
+
+	// Whether the cluster is being polled successfully, which is true once the forwarder deployment
+	// for the cluster has completed its rollout.
+	Polling bool `json:"polling"`
+
+	// Reason that the cluster is not being polled successfully.
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -192,23 +1087,243 @@ func (component *OCMLogForwarder) SetDependencyStatus(dependencyStatus bool) {
 	component.Status.DependenciesSatisfied = dependencyStatus
 }
 
+// GetSecretsValidStatus returns whether the secrets referenced by a component are valid.
+func (component *OCMLogForwarder) GetSecretsValidStatus() bool {
+	return component.Status.SecretsValid
+}
+
+// SetSecretsValidStatus sets whether the secrets referenced by a component are valid.
+func (component *OCMLogForwarder) SetSecretsValidStatus(valid bool) {
+	component.Status.SecretsValid = valid
+}
+
+// GetElasticSearchProvisionedStatus returns whether the elasticsearch resources managed by a component
+// have been provisioned.
+func (component *OCMLogForwarder) GetElasticSearchProvisionedStatus() bool {
+	return component.Status.ElasticSearchProvisioned
+}
+
+// SetElasticSearchProvisionedStatus sets whether the elasticsearch resources managed by a component have
+// been provisioned.
+func (component *OCMLogForwarder) SetElasticSearchProvisionedStatus(provisioned bool) {
+	component.Status.ElasticSearchProvisioned = provisioned
+}
+
+// GetClustersStatus returns the forwarding status of each cluster which logs are forwarded from.
+func (component *OCMLogForwarder) GetClustersStatus() []OCMLogForwarderStatusCluster {
+	return component.Status.Clusters
+}
+
+// SetClustersStatus sets the forwarding status of each cluster which logs are forwarded from.
+func (component *OCMLogForwarder) SetClustersStatus(clusters []OCMLogForwarderStatusCluster) {
+	component.Status.Clusters = clusters
+}
+
+// GetResolvedImageStatus returns the image, pinned to a digest, which the forwarder runs.
+func (component *OCMLogForwarder) GetResolvedImageStatus() string {
+	return component.Status.ResolvedImage
+}
+
+// SetResolvedImageStatus sets the image, pinned to a digest, which the forwarder runs.
+func (component *OCMLogForwarder) SetResolvedImageStatus(image string) {
+	component.Status.ResolvedImage = image
+}
+
+// ImageResolutionRetryInterval is the interval after which a requested version, which could not be resolved, is
+// resolved again.  Resolution is skipped until the interval has passed, so that an unreachable registry does not
+// block each reconciliation.
+const ImageResolutionRetryInterval = 5 * time.Minute
+
+// ResolveImageAnnotation is the annotation which requests that the requested version of the forwarder is
+// resolved again, such as to pick up a newer build of a moving tag such as 'latest'.  The version is resolved
+// again each time the value of the annotation changes.
+const ResolveImageAnnotation = "apps.dustinscott.io/resolve-image"
+
+// GetResolveImageRequest returns the value of the annotation which requests that the requested version of the
+// forwarder is resolved again.
+func (component *OCMLogForwarder) GetResolveImageRequest() string {
+	return component.GetAnnotations()[ResolveImageAnnotation]
+}
+
+// GetResolveImageRequestStatus returns the value of the resolve image annotation when the requested version
+// was last resolved.
+func (component *OCMLogForwarder) GetResolveImageRequestStatus() string {
+	return component.Status.ResolveImageRequest
+}
+
+// SetResolveImageRequestStatus sets the value of the resolve image annotation when the requested version was
+// last resolved.
+func (component *OCMLogForwarder) SetResolveImageRequestStatus(request string) {
+	component.Status.ResolveImageRequest = request
+}
+
+// GetImageResolutionFailureStatus returns the reason that the requested version could not be resolved.
+func (component *OCMLogForwarder) GetImageResolutionFailureStatus() string {
+	return component.Status.ImageResolutionFailure
+}
+
+// SetImageResolutionFailureStatus sets the reason that the requested version could not be resolved, which is
+// empty when the requested version was resolved, along with the time that it could not be resolved.
+func (component *OCMLogForwarder) SetImageResolutionFailureStatus(failure string) {
+	component.Status.ImageResolutionFailure = failure
+
+	if failure == "" {
+		component.Status.ImageResolutionFailureTime = nil
+
+		return
+	}
+
+	now := metav1.Now()
+	component.Status.ImageResolutionFailureTime = &now
+}
+
+// GetImageResolutionRetryAfter returns how long after a time the requested version is resolved again, after it
+// could not be resolved.  Zero is returned when the requested version may be resolved.
+func (component *OCMLogForwarder) GetImageResolutionRetryAfter(now time.Time) time.Duration {
+	failed := component.Status.ImageResolutionFailureTime
+	if component.Status.ImageResolutionFailure == "" || failed == nil {
+		return 0
+	}
+
+	if retryAfter := failed.Add(ImageResolutionRetryInterval).Sub(now); retryAfter > 0 {
+		return retryAfter
+	}
+
+	return 0
+}
+
+// GetSuspendedStatus returns whether forwarding is suspended.
+func (component *OCMLogForwarder) GetSuspendedStatus() bool {
+	return component.Status.Suspended
+}
+
+// SetSuspendedStatus sets whether forwarding is suspended.
+func (component *OCMLogForwarder) SetSuspendedStatus(suspended bool) {
+	component.Status.Suspended = suspended
+}
+
+// GetSuspendedReplicasStatus returns the replica count of each forwarder deployment, by deployment name,
+// when forwarding was last suspended.
+func (component *OCMLogForwarder) GetSuspendedReplicasStatus() map[string]int32 {
+	return component.Status.SuspendedReplicas
+}
+
+// SetSuspendedReplicasStatus sets the replica count of each forwarder deployment, by deployment name, when
+// forwarding was last suspended.
+func (component *OCMLogForwarder) SetSuspendedReplicasStatus(replicas map[string]int32) {
+	component.Status.SuspendedReplicas = replicas
+}
+
 // GetPhaseConditions returns the phase conditions for a component.
 func (component *OCMLogForwarder) GetPhaseConditions() []*status.PhaseCondition {
-	return component.Status.Conditions
+	conditions := make([]*status.PhaseCondition, len(component.Status.Conditions))
+
+	for i := range component.Status.Conditions {
+		conditions[i] = &component.Status.Conditions[i].PhaseCondition
+	}
+
+	return conditions
 }
 
 // SetPhaseCondition sets the phase conditions for a component.
 func (component *OCMLogForwarder) SetPhaseCondition(condition *status.PhaseCondition) {
-	for i, currentCondition := range component.GetPhaseConditions() {
+	for i, currentCondition := range component.Status.Conditions {
 		if currentCondition.Phase == condition.Phase {
-			component.Status.Conditions[i] = condition
+			component.Status.Conditions[i] = component.newCondition(condition)
 
 			return
 		}
 	}
 
 	// phase not found, lets add it to the list.
-	component.Status.Conditions = append(component.Status.Conditions, condition)
+	component.Status.Conditions = append(component.Status.Conditions, component.newCondition(condition))
+}
+
+// newCondition returns the condition for a phase condition, with the status of a completed phase replaced by
+// the status which the phase recorded, for the phases which may complete without reaching their condition.
+func (component *OCMLogForwarder) newCondition(phaseCondition *status.PhaseCondition) *OCMLogForwarderCondition {
+	condition := NewCondition(phaseCondition)
+
+	if setStatus, ok := phaseConditionStatuses[phaseCondition.Phase]; ok && phaseCondition.State == status.PhaseStateComplete {
+		setStatus(&component.Status, condition)
+	}
+
+	return condition
+}
+
+const (
+	// ConditionTypeSecretsValid is the type of the condition which is set by the Validate-Secrets phase.
+	ConditionTypeSecretsValid = "SecretsValid"
+
+	// ConditionTypeImageResolved is the type of the condition which is set by the Resolve-Image phase.
+	ConditionTypeImageResolved = "ImageResolved"
+
+	// ConditionTypeSuspended is the type of the condition which is set by the Suspend phase.
+	ConditionTypeSuspended = "Suspended"
+)
+
+// ConditionReasonImageNotResolved is the reason of the ImageResolved condition when the requested version
+// could not be resolved and the forwarder falls back to the previously resolved or the requested image.
+const ConditionReasonImageNotResolved = "NotResolved"
+
+// ConditionReasonNotSuspended is the reason of the Suspended condition when forwarding is not suspended.
+const ConditionReasonNotSuspended = "NotSuspended"
+
+// phaseConditionTypes are the condition types of the phases which set a condition with a name other than the
+// name of the phase.
+var phaseConditionTypes = map[string]string{
+	"Validate-Secrets": ConditionTypeSecretsValid,
+	"Resolve-Image":    ConditionTypeImageResolved,
+	"Suspend":          ConditionTypeSuspended,
+}
+
+// phaseConditionStatuses set the status of the condition of a completed phase from the status of a component,
+// for the phases which may complete without reaching their condition.
+var phaseConditionStatuses = map[string]func(*OCMLogForwarderStatus, *OCMLogForwarderCondition){
+	"Resolve-Image": func(componentStatus *OCMLogForwarderStatus, condition *OCMLogForwarderCondition) {
+		if componentStatus.ImageResolutionFailure == "" {
+			return
+		}
+
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ConditionReasonImageNotResolved
+		condition.Message = componentStatus.ImageResolutionFailure
+	},
+	"Suspend": func(componentStatus *OCMLogForwarderStatus, condition *OCMLogForwarderCondition) {
+		if componentStatus.Suspended {
+			condition.Message = "Forwarding is suspended; the forwarder deployments are scaled to zero"
+
+			return
+		}
+
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ConditionReasonNotSuspended
+		condition.Message = "Forwarding is not suspended"
+	},
+}
+
+// NewCondition returns the condition for a phase condition, with the type and status of the standard condition
+// which is set by the phase.
+func NewCondition(phaseCondition *status.PhaseCondition) *OCMLogForwarderCondition {
+	condition := &OCMLogForwarderCondition{
+		PhaseCondition: *phaseCondition,
+		Type:           phaseCondition.Phase,
+		Status:         metav1.ConditionUnknown,
+		Reason:         string(phaseCondition.State),
+	}
+
+	if conditionType, ok := phaseConditionTypes[phaseCondition.Phase]; ok {
+		condition.Type = conditionType
+	}
+
+	switch phaseCondition.State {
+	case status.PhaseStateComplete:
+		condition.Status = metav1.ConditionTrue
+	case status.PhaseStateFailed:
+		condition.Status = metav1.ConditionFalse
+	}
+
+	return condition
 }
 
 // GetResources returns the child resource status for a component.
@@ -232,9 +1347,202 @@ func (component *OCMLogForwarder) SetChildResourceCondition(resource *status.Chi
 	component.Status.Resources = append(component.Status.Resources, resource)
 }
 
-// GetDependencies returns the dependencies for a component.
-func (*OCMLogForwarder) GetDependencies() []workload.Workload {
-	return []workload.Workload{}
+// GetDependencies returns the dependencies for a component, which are the ECK managed Elasticsearch objects
+// that are referenced by the elasticsearch backends.
+func (component *OCMLogForwarder) GetDependencies() []workload.Workload {
+	dependencies := []workload.Workload{}
+
+	for _, backend := range component.GetBackends() {
+		if ref := backend.ElasticSearch.ElasticsearchRef; backend.Type == BackendTypeElasticSearch && ref.Name != "" {
+			dependencies = append(dependencies, NewElasticsearchDependency(component.GetElasticsearchRefNamespace(ref), ref.Name))
+		}
+	}
+
+	return dependencies
+}
+
+// GetElasticsearchRefNamespace returns the namespace of a referenced ECK managed Elasticsearch, which defaults
+// to the namespace of the component.
+func (component *OCMLogForwarder) GetElasticsearchRefNamespace(ref OCMLogForwarderSpecBackendElasticSearchRef) string {
+	if ref.Namespace == "" {
+		return component.Namespace
+	}
+
+	return ref.Namespace
+}
+
+// ReferencesElasticsearch returns whether any elasticsearch backend of the component references an ECK managed
+// Elasticsearch.
+func (component *OCMLogForwarder) ReferencesElasticsearch(namespace, name string) bool {
+	for _, dependency := range component.GetDependencies() {
+		if dependency.GetNamespace() == namespace && dependency.GetName() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetBackends returns the backends where logs are sent and stored.  If the .spec.backends field is unset, the
+// single backend from the .spec.backend field is returned as an unnamed backend.
+func (component *OCMLogForwarder) GetBackends() []OCMLogForwarderSpecNamedBackend {
+	if len(component.Spec.Backends) > 0 {
+		return component.Spec.Backends
+	}
+
+	return []OCMLogForwarderSpecNamedBackend{{OCMLogForwarderSpecBackend: component.Spec.Backend}}
+}
+
+// GetClusters returns the clusters which logs are forwarded from, with the secretRef of each cluster
+// defaulted from the .spec.ocm.secretRef field.  If the .spec.ocm.clusters field is unset, the single
+// cluster from the .spec.ocm.clusterId field is returned.
+func (component *OCMLogForwarder) GetClusters() []OCMLogForwarderSpecOcmCluster {
+	if len(component.Spec.Ocm.Clusters) == 0 {
+		return []OCMLogForwarderSpecOcmCluster{
+			{
+				ClusterId: component.Spec.Ocm.ClusterId,
+				SecretRef: component.Spec.Ocm.SecretRef,
+			},
+		}
+	}
+
+	clusters := make([]OCMLogForwarderSpecOcmCluster, len(component.Spec.Ocm.Clusters))
+
+	for i := range component.Spec.Ocm.Clusters {
+		clusters[i] = *component.Spec.Ocm.Clusters[i].DeepCopy()
+
+		if clusters[i].SecretRef == "" {
+			clusters[i].SecretRef = component.Spec.Ocm.SecretRef
+		}
+	}
+
+	return clusters
+}
+
+// GetOCMSecretRefs returns the unique names of the secrets which contain the OCM tokens of the clusters
+// which logs are forwarded from.
+func (component *OCMLogForwarder) GetOCMSecretRefs() []string {
+	secretRefs := []string{}
+
+	for _, cluster := range component.GetClusters() {
+		secretRefs = append(secretRefs, cluster.SecretRef)
+	}
+
+	return uniqueNames(secretRefs)
+}
+
+// GetSecretRefs returns the unique names of the secrets, in the namespace of the component, which are
+// referenced by the component.
+func (component *OCMLogForwarder) GetSecretRefs() []string {
+	secretRefs := component.GetOCMSecretRefs()
+
+	for _, backend := range component.GetBackends() {
+		secretRefs = append(secretRefs, backend.GetSecretRefs()...)
+	}
+
+	return uniqueNames(secretRefs)
+}
+
+// GetFieldPath returns the path of the field which configures the backend, for use in messages.
+func (backend *OCMLogForwarderSpecNamedBackend) GetFieldPath() string {
+	if backend.Name == "" {
+		return ".spec.backend"
+	}
+
+	return ".spec.backends[" + backend.Name + "]"
+}
+
+// GetSecretRefs returns the names of the secrets which are referenced by the backend selected by the type
+// field.
+func (backend *OCMLogForwarderSpecBackend) GetSecretRefs() []string {
+	switch backend.Type {
+	case BackendTypeElasticSearch:
+		secretRefs := []string{backend.ElasticSearch.SecretRef}
+
+		if backend.ElasticSearch.TLS.CARef.Kind == CARefKindSecret {
+			secretRefs = append(secretRefs, backend.ElasticSearch.TLS.CARef.Name)
+		}
+
+		return uniqueNames(append(secretRefs, backend.ElasticSearch.TLS.ClientCertSecretRef))
+	case BackendTypeSplunk:
+		return uniqueNames([]string{backend.Splunk.SecretRef})
+	case BackendTypeLoki:
+		if backend.Loki.AuthType != LokiAuthTypeNone {
+			return uniqueNames([]string{backend.Loki.SecretRef})
+		}
+	case BackendTypeKafka:
+		if backend.Kafka.AuthType != KafkaAuthTypeNone {
+			return uniqueNames([]string{backend.Kafka.SecretRef})
+		}
+	case BackendTypeS3:
+		return uniqueNames([]string{backend.S3.SecretRef})
+	}
+
+	return []string{}
+}
+
+// SetDefaults sets the defaults of the custom resource definition on the OCM and backend fields which are
+// required to generate the child resources.  The defaults are otherwise set by the API server, so this is only
+// needed for a spec which is not read from the API server, such as one which is generated from the CLI.
+func (spec *OCMLogForwarderSpec) SetDefaults() {
+	spec.Ocm.setDefaults()
+
+	if len(spec.Backends) == 0 {
+		spec.Backend.setDefaults()
+
+		return
+	}
+
+	for i := range spec.Backends {
+		spec.Backends[i].setDefaults()
+	}
+}
+
+// setDefaults sets the defaults of the custom resource definition on the OCM configuration.
+func (ocm *OCMLogForwarderSpecOcm) setDefaults() {
+	for _, field := range []struct {
+		value        *string
+		defaultValue string
+	}{
+		{value: &ocm.SecretRef, defaultValue: DefaultOCMSecretRef},
+		{value: &ocm.AuthType, defaultValue: OCMAuthTypeOfflineToken},
+		{value: &ocm.ApiURL, defaultValue: OCMDefaultAPIURL},
+		{value: &ocm.TokenURL, defaultValue: OCMDefaultTokenURL},
+	} {
+		if *field.value == "" {
+			*field.value = field.defaultValue
+		}
+	}
+
+	if ocm.PollInternalMinutes == 0 {
+		ocm.PollInternalMinutes = DefaultOCMPollIntervalMinutes
+	}
+}
+
+// setDefaults sets the defaults of the custom resource definition on the type of the backend, and on the
+// fields of an elasticsearch backend which does not reference an ECK managed Elasticsearch.
+func (backend *OCMLogForwarderSpecBackend) setDefaults() {
+	if backend.Type == "" {
+		backend.Type = BackendTypeElasticSearch
+	}
+
+	if backend.Type != BackendTypeElasticSearch || backend.ElasticSearch.ElasticsearchRef.Name != "" {
+		return
+	}
+
+	for _, field := range []struct {
+		value        *string
+		defaultValue string
+	}{
+		{value: &backend.ElasticSearch.Url, defaultValue: DefaultElasticSearchURL},
+		{value: &backend.ElasticSearch.SecretRef, defaultValue: DefaultElasticSearchSecretRef},
+		{value: &backend.ElasticSearch.AuthType, defaultValue: ElasticSearchAuthTypeBasic},
+		{value: &backend.ElasticSearch.Index, defaultValue: DefaultElasticSearchIndex},
+	} {
+		if *field.value == "" {
+			*field.value = field.defaultValue
+		}
+	}
 }
 
 // GetComponentGVK returns a GVK object for the component.
@@ -242,6 +1550,24 @@ func (*OCMLogForwarder) GetWorkloadGVK() schema.GroupVersionKind {
 	return GroupVersion.WithKind("OCMLogForwarder")
 }
 
+// uniqueNames returns the non-empty names from a list of names with duplicates removed, preserving
+// the original order.
+func uniqueNames(names []string) []string {
+	found := map[string]bool{}
+	unique := []string{}
+
+	for _, name := range names {
+		if name == "" || found[name] {
+			continue
+		}
+
+		found[name] = true
+		unique = append(unique, name)
+	}
+
+	return unique
+}
+
 func init() {
 	SchemeBuilder.Register(&OCMLogForwarder{}, &OCMLogForwarderList{})
 }
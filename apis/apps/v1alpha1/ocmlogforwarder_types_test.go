@@ -0,0 +1,171 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetPhaseCondition(t *testing.T) {
+	component := &OCMLogForwarder{}
+
+	for _, tt := range []struct {
+		name          string
+		condition     status.PhaseCondition
+		conditionType string
+		status        metav1.ConditionStatus
+	}{
+		{
+			name:          "pending phase",
+			condition:     status.GetPendingCondition("Check-Ready"),
+			conditionType: "Check-Ready",
+			status:        metav1.ConditionUnknown,
+		},
+		{
+			name:          "completed phase",
+			condition:     status.GetSuccessCondition("Check-Ready"),
+			conditionType: "Check-Ready",
+			status:        metav1.ConditionTrue,
+		},
+		{
+			name:          "failed secret validation",
+			condition:     status.GetFailCondition("Validate-Secrets", errors.New("invalid secret")),
+			conditionType: ConditionTypeSecretsValid,
+			status:        metav1.ConditionFalse,
+		},
+		{
+			name:          "completed secret validation",
+			condition:     status.GetSuccessCondition("Validate-Secrets"),
+			conditionType: ConditionTypeSecretsValid,
+			status:        metav1.ConditionTrue,
+		},
+	} {
+		condition := tt.condition
+		component.SetPhaseCondition(&condition)
+
+		var found *OCMLogForwarderCondition
+
+		for _, current := range component.Status.Conditions {
+			if current.Phase == tt.condition.Phase {
+				found = current
+			}
+		}
+
+		if found == nil {
+			t.Fatalf("%s: expected condition for phase [%s]", tt.name, tt.condition.Phase)
+		}
+
+		if found.Type != tt.conditionType || found.Status != tt.status || found.Message != tt.condition.Message {
+			t.Errorf("%s: expected condition [%s=%s] with message [%s]; found [%s=%s] with message [%s]",
+				tt.name, tt.conditionType, tt.status, tt.condition.Message, found.Type, found.Status, found.Message)
+		}
+	}
+
+	if len(component.Status.Conditions) != 2 || len(component.GetPhaseConditions()) != 2 {
+		t.Errorf("expected a single condition for each phase; found %d", len(component.Status.Conditions))
+	}
+}
+
+func TestGetDeploymentReplicas(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		suspend  bool
+		replicas map[string]int32
+		want     *int32
+	}{
+		{name: "not suspended", want: nil},
+		{name: "not suspended with recorded replicas", replicas: map[string]int32{"forwarder": 3}, want: nil},
+		{name: "suspended", suspend: true, want: new(int32)},
+		{name: "suspended with recorded replicas", suspend: true, replicas: map[string]int32{"forwarder": 3}, want: new(int32)},
+	} {
+		component := &OCMLogForwarder{
+			Spec:   OCMLogForwarderSpec{Suspend: tt.suspend},
+			Status: OCMLogForwarderStatus{SuspendedReplicas: tt.replicas},
+		}
+
+		found := component.GetDeploymentReplicas()
+
+		if (found == nil) != (tt.want == nil) || (found != nil && *found != *tt.want) {
+			t.Errorf("%s: expected replicas [%v]; found [%v]", tt.name, tt.want, found)
+		}
+	}
+}
+
+func TestGetResumedReplicas(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		replicas map[string]int32
+		want     int32
+	}{
+		{name: "no recorded replicas", want: DefaultReplicas},
+		{name: "recorded replicas", replicas: map[string]int32{"forwarder": 3}, want: 3},
+		{name: "recorded replicas of another deployment", replicas: map[string]int32{"forwarder-abc123": 3}, want: DefaultReplicas},
+		{name: "recorded zero replicas", replicas: map[string]int32{"forwarder": 0}, want: DefaultReplicas},
+	} {
+		component := &OCMLogForwarder{Status: OCMLogForwarderStatus{SuspendedReplicas: tt.replicas}}
+
+		if found := component.GetResumedReplicas("forwarder"); found != tt.want {
+			t.Errorf("%s: expected replicas [%d]; found [%d]", tt.name, tt.want, found)
+		}
+	}
+}
+
+func TestGetImageResolutionRetryAfter(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name    string
+		failure string
+		failed  time.Duration
+		want    time.Duration
+	}{
+		{name: "resolved", want: 0},
+		{name: "failed without a time", failure: "unable to resolve image", want: 0},
+		{name: "failed recently", failure: "unable to resolve image", failed: time.Minute, want: ImageResolutionRetryInterval - time.Minute},
+		{name: "failed before the retry interval", failure: "unable to resolve image", failed: ImageResolutionRetryInterval, want: 0},
+	} {
+		component := &OCMLogForwarder{Status: OCMLogForwarderStatus{ImageResolutionFailure: tt.failure}}
+
+		if tt.failed != 0 {
+			failed := metav1.NewTime(now.Add(-tt.failed))
+			component.Status.ImageResolutionFailureTime = &failed
+		}
+
+		if found := component.GetImageResolutionRetryAfter(now); found != tt.want {
+			t.Errorf("%s: expected retry after [%s]; found [%s]", tt.name, tt.want, found)
+		}
+	}
+
+	component := &OCMLogForwarder{}
+
+	component.SetImageResolutionFailureStatus("unable to resolve image")
+
+	if component.Status.ImageResolutionFailureTime == nil {
+		t.Errorf("expected the time of the failure to be recorded")
+	}
+
+	component.SetImageResolutionFailureStatus("")
+
+	if component.Status.ImageResolutionFailureTime != nil {
+		t.Errorf("expected the time of the failure to be cleared")
+	}
+}
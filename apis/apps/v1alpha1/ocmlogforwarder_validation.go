@@ -0,0 +1,738 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var ErrInvalidSpec = errors.New("invalid spec")
+
+// ocmClusterID is the expression which all cluster ids in a list of clusters must match.  Cluster ids are
+// used in the names of the resources which are created for each cluster.
+var ocmClusterID = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// imageRepository is the expression which the image repository of the forwarder must match, which is a
+// registry host with an optional port followed by the path of the repository, without a tag or digest.
+var imageRepository = regexp.MustCompile(
+	`^[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?(:[0-9]+)?(/[a-z0-9]+([._-]+[a-z0-9]+)*)+$`,
+)
+
+// imageTag is the expression which a version of the forwarder which is a tag must match.
+var imageTag = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$`)
+
+// imageDigest is the expression which a version of the forwarder which is a digest must match.
+var imageDigest = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// lokiLabelName is the expression which all loki stream label names must match.
+var lokiLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// kafkaTopicName is the expression which all kafka topic names must match.
+var kafkaTopicName = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+// s3BucketName is the expression which all s3 bucket names must match.
+var s3BucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// s3PrefixPlaceholder is the expression which matches a placeholder in an s3 prefix template.
+var s3PrefixPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// s3PrefixPlaceholders are the placeholders which are supported in an s3 prefix template.
+var s3PrefixPlaceholders = map[string]bool{
+	"{clusterId}": true,
+	"{year}":      true,
+	"{month}":     true,
+	"{day}":       true,
+}
+
+// elasticSearchDateMath is the expression which matches the date math in an elasticsearch index pattern.
+var elasticSearchDateMath = regexp.MustCompile(`\{[^{}]*\}`)
+
+// elasticSearchDateMathFormat is the expression which the format within each date math expression of an
+// elasticsearch index pattern must match.
+var elasticSearchDateMathFormat = regexp.MustCompile(`^(yyyy|yy|MM|dd|HH)([._-]?(yyyy|yy|MM|dd|HH))*$`)
+
+// elasticSearchDataStreamNamespaceMaxLength is the maximum length of the namespace of a data stream in the
+// ElasticSearch data stream naming scheme.
+const elasticSearchDataStreamNamespaceMaxLength = 100
+
+// ValidateSpec validates the fields of a component which cannot be validated by the schema of the
+// custom resource definition alone.
+func (component *OCMLogForwarder) ValidateSpec() error {
+	if err := component.Spec.Ocm.validate(); err != nil {
+		return err
+	}
+
+	if err := component.validateClusterIDLength(); err != nil {
+		return err
+	}
+
+	if err := component.Spec.Proxy.validate(); err != nil {
+		return err
+	}
+
+	if err := component.Spec.Filter.validate(); err != nil {
+		return err
+	}
+
+	if err := component.validateImage(); err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+
+	for i := range component.Spec.Backends {
+		name := component.Spec.Backends[i].Name
+
+		if name == "" {
+			return fmt.Errorf("%w; .spec.backends[%d].name must be set", ErrInvalidSpec, i)
+		}
+
+		if names[name] {
+			return fmt.Errorf("%w; .spec.backends contains duplicate name [%s]", ErrInvalidSpec, name)
+		}
+
+		names[name] = true
+	}
+
+	for _, backend := range component.GetBackends() {
+		if err := backend.validate(); err != nil {
+			return err
+		}
+
+		// each cluster index override would be written to as a plain index, which cannot be matched by the
+		// index template of the data stream.
+		if backend.Type == BackendTypeElasticSearch && backend.ElasticSearch.DataStream.Enabled {
+			for _, cluster := range component.Spec.Ocm.Clusters {
+				if cluster.Index != "" {
+					return fmt.Errorf(
+						"%w; .spec.ocm.clusters[%s].index cannot be used with %s.elasticSearch.dataStream",
+						ErrInvalidSpec,
+						cluster.ClusterId,
+						backend.GetFieldPath(),
+					)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate validates the ocm configuration.
+func (ocm *OCMLogForwarderSpecOcm) validate() error {
+	switch {
+	case ocm.ClusterId == "" && len(ocm.Clusters) == 0:
+		return fmt.Errorf("%w; one of .spec.ocm.clusterId or .spec.ocm.clusters must be set", ErrInvalidSpec)
+	case ocm.ClusterId != "" && len(ocm.Clusters) > 0:
+		return fmt.Errorf("%w; only one of .spec.ocm.clusterId or .spec.ocm.clusters may be set", ErrInvalidSpec)
+	}
+
+	if ocm.ClusterId != "" && !ocmClusterID.MatchString(ocm.ClusterId) {
+		return fmt.Errorf(
+			"%w; .spec.ocm.clusterId [%s] must match regex [%s]",
+			ErrInvalidSpec,
+			ocm.ClusterId,
+			ocmClusterID.String(),
+		)
+	}
+
+	if err := validateSecretRef(ocm.SecretRef, ".spec.ocm.secretRef"); err != nil {
+		return err
+	}
+
+	if err := validateURL(ocm.GetAPIURL(), ".spec.ocm.apiURL"); err != nil {
+		return err
+	}
+
+	if err := validateURL(ocm.GetTokenURL(), ".spec.ocm.tokenURL"); err != nil {
+		return err
+	}
+
+	clusterIDs := map[string]bool{}
+
+	for i, cluster := range ocm.Clusters {
+		if !ocmClusterID.MatchString(cluster.ClusterId) {
+			return fmt.Errorf(
+				"%w; .spec.ocm.clusters[%d].clusterId [%s] must match regex [%s]",
+				ErrInvalidSpec,
+				i,
+				cluster.ClusterId,
+				ocmClusterID.String(),
+			)
+		}
+
+		if clusterIDs[cluster.ClusterId] {
+			return fmt.Errorf("%w; .spec.ocm.clusters contains duplicate clusterId [%s]", ErrInvalidSpec, cluster.ClusterId)
+		}
+
+		clusterIDs[cluster.ClusterId] = true
+
+		if err := validateSecretRef(cluster.SecretRef, fmt.Sprintf(".spec.ocm.clusters[%s].secretRef", cluster.ClusterId)); err != nil {
+			return err
+		}
+
+		if err := validateLokiLabels(cluster.Labels, fmt.Sprintf(".spec.ocm.clusters[%s].labels", cluster.ClusterId)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateClusterIDLength validates the length of each cluster id in a list of clusters, which is used as the
+// value of the cluster id label and as the suffix of the name of the deployment that forwards logs for the
+// cluster, which is the name of the component followed by '-' and the cluster id.
+func (component *OCMLogForwarder) validateClusterIDLength() error {
+	for i, cluster := range component.Spec.Ocm.Clusters {
+		if len(cluster.ClusterId) > validation.LabelValueMaxLength {
+			return fmt.Errorf(
+				"%w; .spec.ocm.clusters[%d].clusterId [%s] must be no more than %d characters",
+				ErrInvalidSpec,
+				i,
+				cluster.ClusterId,
+				validation.LabelValueMaxLength,
+			)
+		}
+
+		if length := len(component.Name) + len("-") + len(cluster.ClusterId); length > validation.DNS1123SubdomainMaxLength {
+			return fmt.Errorf(
+				"%w; .spec.ocm.clusters[%d].clusterId [%s] results in a deployment name of %d characters, "+
+					"which must be no more than %d characters",
+				ErrInvalidSpec,
+				i,
+				cluster.ClusterId,
+				length,
+				validation.DNS1123SubdomainMaxLength,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validate validates the filter configuration.
+func (filter *OCMLogForwarderSpecFilter) validate() error {
+	switch filter.MinSeverity {
+	case "", SeverityDebug, SeverityInfo, SeverityWarning, SeverityError, SeverityFatal:
+	default:
+		return fmt.Errorf(
+			"%w; .spec.filter.minSeverity must be one of [%s, %s, %s, %s, %s]; found [%s]",
+			ErrInvalidSpec,
+			SeverityDebug,
+			SeverityInfo,
+			SeverityWarning,
+			SeverityError,
+			SeverityFatal,
+			filter.MinSeverity,
+		)
+	}
+
+	included := map[string]bool{}
+
+	for _, name := range filter.IncludeServiceNames {
+		if name == "" || strings.Contains(name, ",") {
+			return fmt.Errorf("%w; .spec.filter.includeServiceNames contains invalid service name [%s]", ErrInvalidSpec, name)
+		}
+
+		included[name] = true
+	}
+
+	for _, name := range filter.ExcludeServiceNames {
+		if name == "" || strings.Contains(name, ",") {
+			return fmt.Errorf("%w; .spec.filter.excludeServiceNames contains invalid service name [%s]", ErrInvalidSpec, name)
+		}
+
+		if included[name] {
+			return fmt.Errorf(
+				"%w; service name [%s] cannot be in both .spec.filter.includeServiceNames and .spec.filter.excludeServiceNames",
+				ErrInvalidSpec,
+				name,
+			)
+		}
+	}
+
+	if filter.SummaryRegex != "" {
+		if _, err := regexp.Compile(filter.SummaryRegex); err != nil {
+			return fmt.Errorf("%w; .spec.filter.summaryRegex is not a valid regular expression; %s", ErrInvalidSpec, err.Error())
+		}
+	}
+
+	// a maxAgeMinutes of 0 is unset, which forwards service logs of any age.
+	if filter.MaxAgeMinutes < 0 {
+		return fmt.Errorf("%w; .spec.filter.maxAgeMinutes must not be negative; found [%d]", ErrInvalidSpec, filter.MaxAgeMinutes)
+	}
+
+	return nil
+}
+
+// validate validates the proxy configuration.
+func (proxy *OCMLogForwarderSpecProxy) validate() error {
+	if proxy.HttpProxy != "" {
+		if err := validateURL(proxy.HttpProxy, ".spec.proxy.httpProxy"); err != nil {
+			return err
+		}
+	}
+
+	if proxy.HttpsProxy != "" {
+		if err := validateURL(proxy.HttpsProxy, ".spec.proxy.httpsProxy"); err != nil {
+			return err
+		}
+	}
+
+	if strings.ContainsAny(proxy.NoProxy, " \t\n") {
+		return fmt.Errorf("%w; .spec.proxy.noProxy [%s] must be a comma-separated list without spaces", ErrInvalidSpec, proxy.NoProxy)
+	}
+
+	return nil
+}
+
+// validate validates the configuration of the backend selected by the type field.
+func (backend *OCMLogForwarderSpecNamedBackend) validate() error {
+	field := backend.GetFieldPath()
+
+	switch backend.Type {
+	case BackendTypeElasticSearch:
+		return backend.ElasticSearch.validate(field + ".elasticSearch")
+	case BackendTypeSplunk:
+		return backend.Splunk.validate(field + ".splunk")
+	case BackendTypeLoki:
+		return backend.Loki.validate(field + ".loki")
+	case BackendTypeKafka:
+		return backend.Kafka.validate(field + ".kafka")
+	case BackendTypeS3:
+		return backend.S3.validate(field + ".s3")
+	}
+
+	return fmt.Errorf(
+		"%w; %s.type must be one of [%s, %s, %s, %s, %s]; found [%s]",
+		ErrInvalidSpec,
+		field,
+		BackendTypeElasticSearch,
+		BackendTypeSplunk,
+		BackendTypeLoki,
+		BackendTypeKafka,
+		BackendTypeS3,
+		backend.Type,
+	)
+}
+
+// validate validates the elasticsearch backend configuration.
+func (elasticSearch *OCMLogForwarderSpecBackendElasticSearch) validate(field string) error {
+	if err := elasticSearch.validateIndex(field); err != nil {
+		return err
+	}
+
+	if ref := elasticSearch.ElasticsearchRef; ref.Name != "" {
+		// the url, credentials and certificate authority are resolved from the referenced elasticsearch, so
+		// there is nothing further to validate.
+		return nil
+	} else if ref.Namespace != "" {
+		return fmt.Errorf("%w; %s.elasticsearchRef.name must be set when namespace is set", ErrInvalidSpec, field)
+	}
+
+	if err := validateURL(elasticSearch.Url, field+".url"); err != nil {
+		return err
+	}
+
+	if err := validateSecretRef(elasticSearch.SecretRef, field+".secretRef"); err != nil {
+		return err
+	}
+
+	if err := validateSecretRef(elasticSearch.TLS.ClientCertSecretRef, field+".tls.clientCertSecretRef"); err != nil {
+		return err
+	}
+
+	caRef := elasticSearch.TLS.CARef
+
+	if caRef.Name == "" {
+		return nil
+	}
+
+	if err := validateSecretRef(caRef.Name, field+".tls.caRef.name"); err != nil {
+		return err
+	}
+
+	if caRef.Kind != CARefKindSecret && caRef.Kind != CARefKindConfigMap {
+		return fmt.Errorf(
+			"%w; %s.tls.caRef.kind must be one of [%s, %s]; found [%s]",
+			ErrInvalidSpec,
+			field,
+			CARefKindSecret,
+			CARefKindConfigMap,
+			caRef.Kind,
+		)
+	}
+
+	if caRef.Key == "" {
+		return fmt.Errorf("%w; %s.tls.caRef.key must be set", ErrInvalidSpec, field)
+	}
+
+	return nil
+}
+
+// validateIndex validates the index pattern and index lifecycle management configuration of the
+// elasticsearch backend.
+func (elasticSearch *OCMLogForwarderSpecBackendElasticSearch) validateIndex(field string) error {
+	if elasticSearch.IndexPattern != "" {
+		if err := validateElasticSearchIndexPattern(elasticSearch.IndexPattern, field+".indexPattern"); err != nil {
+			return err
+		}
+	}
+
+	if elasticSearch.DataStream.Enabled {
+		if err := elasticSearch.validateDataStream(field); err != nil {
+			return err
+		}
+	}
+
+	if elasticSearch.Retention != "" && !elasticSearch.ManageIndexTemplate && !elasticSearch.DataStream.Enabled {
+		return fmt.Errorf(
+			"%w; %s.manageIndexTemplate or %s.dataStream.enabled must be true when retention is set",
+			ErrInvalidSpec,
+			field,
+			field,
+		)
+	}
+
+	ilm := elasticSearch.ILM
+
+	if ilm.PolicyName != "" && (strings.HasPrefix(ilm.PolicyName, "_") || strings.ContainsAny(ilm.PolicyName, " ,")) {
+		return fmt.Errorf(
+			"%w; %s.ilm.policyName [%s] must not begin with '_' or contain spaces or commas",
+			ErrInvalidSpec,
+			field,
+			ilm.PolicyName,
+		)
+	}
+
+	if ilm.RolloverAlias == "" {
+		return nil
+	}
+
+	if elasticSearch.IndexPattern != "" {
+		return fmt.Errorf("%w; %s.ilm.rolloverAlias cannot be used with %s.indexPattern", ErrInvalidSpec, field, field)
+	}
+
+	if ilm.PolicyName == "" {
+		return fmt.Errorf("%w; %s.ilm.policyName must be set when rolloverAlias is set", ErrInvalidSpec, field)
+	}
+
+	return validateElasticSearchIndexName(ilm.RolloverAlias, field+".ilm.rolloverAlias")
+}
+
+// validateDataStream validates the data stream configuration of the elasticsearch backend against the
+// ElasticSearch data stream naming scheme.
+func (elasticSearch *OCMLogForwarderSpecBackendElasticSearch) validateDataStream(field string) error {
+	if elasticSearch.IndexPattern != "" || elasticSearch.ILM.RolloverAlias != "" {
+		return fmt.Errorf(
+			"%w; %s.dataStream cannot be used with %s.indexPattern or %s.ilm.rolloverAlias",
+			ErrInvalidSpec,
+			field,
+			field,
+			field,
+		)
+	}
+
+	namespace := elasticSearch.DataStream.Namespace
+
+	switch {
+	case strings.Contains(namespace, "-"):
+		return fmt.Errorf("%w; %s.dataStream.namespace [%s] must not contain '-'", ErrInvalidSpec, field, namespace)
+	case len(namespace) > elasticSearchDataStreamNamespaceMaxLength:
+		return fmt.Errorf(
+			"%w; %s.dataStream.namespace [%s] must be %d characters or less",
+			ErrInvalidSpec,
+			field,
+			namespace,
+			elasticSearchDataStreamNamespaceMaxLength,
+		)
+	}
+
+	name := elasticSearch.DataStream.GetName()
+	if problem := elasticSearchIndexNameProblem(name); problem != "" {
+		return fmt.Errorf("%w; %s.dataStream.namespace results in data stream [%s] which %s", ErrInvalidSpec, field, name, problem)
+	}
+
+	return nil
+}
+
+// validateElasticSearchIndexPattern validates that a field contains an elasticsearch index name with
+// optional date math.
+func validateElasticSearchIndexPattern(pattern, field string) error {
+	for _, dateMath := range elasticSearchDateMath.FindAllString(pattern, -1) {
+		if !elasticSearchDateMathFormat.MatchString(strings.Trim(dateMath, "{}")) {
+			return fmt.Errorf(
+				"%w; %s [%s] contains unsupported date math [%s]",
+				ErrInvalidSpec,
+				field,
+				pattern,
+				dateMath,
+			)
+		}
+	}
+
+	static := elasticSearchDateMath.ReplaceAllString(pattern, "")
+	if strings.ContainsAny(static, "{}") {
+		return fmt.Errorf("%w; %s [%s] contains unbalanced braces", ErrInvalidSpec, field, pattern)
+	}
+
+	// the date math is resolved to digits, so an index pattern which begins with date math is valid as
+	// long as the remainder of the pattern is.
+	if strings.HasPrefix(pattern, "{") {
+		static = "0" + static
+	}
+
+	if problem := elasticSearchIndexNameProblem(static); problem != "" {
+		return fmt.Errorf("%w; %s [%s] %s", ErrInvalidSpec, field, pattern, problem)
+	}
+
+	return nil
+}
+
+// validateElasticSearchIndexName validates that a field contains a valid elasticsearch index name.
+func validateElasticSearchIndexName(name, field string) error {
+	if problem := elasticSearchIndexNameProblem(name); problem != "" {
+		return fmt.Errorf("%w; %s [%s] %s", ErrInvalidSpec, field, name, problem)
+	}
+
+	return nil
+}
+
+// elasticSearchIndexNameProblem returns the reason that a name is not a valid elasticsearch index name, or
+// an empty string if the name is valid.
+func elasticSearchIndexNameProblem(name string) string {
+	switch {
+	case name == "", name == ".", name == "..":
+		return "is not a valid index name"
+	case strings.ToLower(name) != name:
+		return "must be lowercase"
+	case strings.ContainsAny(name, "\\/*?\"<>| ,#:"):
+		return "must not contain any of the characters [\\ / * ? \" < > | , # :] or spaces"
+	case strings.HasPrefix(name, "-"), strings.HasPrefix(name, "_"), strings.HasPrefix(name, "+"):
+		return "must not begin with '-', '_' or '+'"
+	}
+
+	return ""
+}
+
+// validate validates the splunk backend configuration.
+func (splunk *OCMLogForwarderSpecBackendSplunk) validate(field string) error {
+	if err := validateURL(splunk.Url, field+".url"); err != nil {
+		return err
+	}
+
+	return validateSecretRef(splunk.SecretRef, field+".secretRef")
+}
+
+// validate validates the loki backend configuration.
+func (loki *OCMLogForwarderSpecBackendLoki) validate(field string) error {
+	if err := validateURL(loki.Url, field+".url"); err != nil {
+		return err
+	}
+
+	if err := validateSecretRef(loki.SecretRef, field+".secretRef"); err != nil {
+		return err
+	}
+
+	return validateLokiLabels(loki.Labels, field+".labels")
+}
+
+// validateLokiLabels validates that the names of loki stream labels from a field are valid.
+func validateLokiLabels(labels map[string]string, field string) error {
+	names := make([]string, 0, len(labels))
+
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !lokiLabelName.MatchString(name) {
+			return fmt.Errorf(
+				"%w; label name [%s] from %s must match regex [%s]",
+				ErrInvalidSpec,
+				name,
+				field,
+				lokiLabelName.String(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// validate validates the kafka backend configuration.
+func (kafka *OCMLogForwarderSpecBackendKafka) validate(field string) error {
+	if len(kafka.BootstrapServers) == 0 {
+		return fmt.Errorf("%w; %s.bootstrapServers must be set", ErrInvalidSpec, field)
+	}
+
+	for _, server := range kafka.BootstrapServers {
+		if err := validateHostPort(server, field+".bootstrapServers"); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSecretRef(kafka.SecretRef, field+".secretRef"); err != nil {
+		return err
+	}
+
+	if !kafkaTopicName.MatchString(kafka.Topic) {
+		return fmt.Errorf(
+			"%w; %s.topic [%s] must match regex [%s]",
+			ErrInvalidSpec,
+			field,
+			kafka.Topic,
+			kafkaTopicName.String(),
+		)
+	}
+
+	return nil
+}
+
+// validate validates the s3 backend configuration.
+func (s3 *OCMLogForwarderSpecBackendS3) validate(field string) error {
+	if s3.Endpoint != "" {
+		if err := validateURL(s3.Endpoint, field+".endpoint"); err != nil {
+			return err
+		}
+	}
+
+	if s3.Bucket == "" {
+		return fmt.Errorf("%w; %s.bucket must be set", ErrInvalidSpec, field)
+	}
+
+	if err := validateSecretRef(s3.SecretRef, field+".secretRef"); err != nil {
+		return err
+	}
+
+	if !s3BucketName.MatchString(s3.Bucket) {
+		return fmt.Errorf(
+			"%w; %s.bucket [%s] must match regex [%s]",
+			ErrInvalidSpec,
+			field,
+			s3.Bucket,
+			s3BucketName.String(),
+		)
+	}
+
+	if strings.HasPrefix(s3.Prefix, "/") {
+		return fmt.Errorf("%w; %s.prefix [%s] must not begin with '/'", ErrInvalidSpec, field, s3.Prefix)
+	}
+
+	for _, placeholder := range s3PrefixPlaceholder.FindAllString(s3.Prefix, -1) {
+		if !s3PrefixPlaceholders[placeholder] {
+			return fmt.Errorf(
+				"%w; %s.prefix [%s] contains unsupported placeholder [%s]",
+				ErrInvalidSpec,
+				field,
+				s3.Prefix,
+				placeholder,
+			)
+		}
+	}
+
+	if strings.ContainsAny(s3PrefixPlaceholder.ReplaceAllString(s3.Prefix, ""), "{}") {
+		return fmt.Errorf("%w; %s.prefix [%s] contains unbalanced braces", ErrInvalidSpec, field, s3.Prefix)
+	}
+
+	return nil
+}
+
+// validateHostPort validates that a value from a field is in host:port form.
+func validateHostPort(hostPort, field string) error {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil || host == "" {
+		return fmt.Errorf("%w; %s must be in host:port form; found [%s]", ErrInvalidSpec, field, hostPort)
+	}
+
+	if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+		return fmt.Errorf("%w; %s has an invalid port; found [%s]", ErrInvalidSpec, field, hostPort)
+	}
+
+	return nil
+}
+
+// validateImage validates the image repository and the version of the forwarder.
+func (component *OCMLogForwarder) validateImage() error {
+	if image := component.GetImage(); !imageRepository.MatchString(image) {
+		return fmt.Errorf(
+			"%w; .spec.image [%s] must be an image repository without a tag or digest, matching regex [%s]",
+			ErrInvalidSpec,
+			image,
+			imageRepository.String(),
+		)
+	}
+
+	version, expression := component.GetVersion(), imageTag
+	if component.VersionIsDigest() {
+		expression = imageDigest
+	}
+
+	if !expression.MatchString(version) {
+		return fmt.Errorf(
+			"%w; .spec.version [%s] must be a tag or a sha256 digest, matching regex [%s]",
+			ErrInvalidSpec,
+			version,
+			expression.String(),
+		)
+	}
+
+	return nil
+}
+
+// validateSecretRef validates that a field, when set, contains a valid name of a secret or config map, which
+// must be a DNS-1123 subdomain.
+func validateSecretRef(name, field string) error {
+	if name == "" {
+		return nil
+	}
+
+	if problems := validation.IsDNS1123Subdomain(name); len(problems) > 0 {
+		return fmt.Errorf("%w; %s [%s] is not a valid name; %s", ErrInvalidSpec, field, name, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// validateURL validates that a field contains an absolute http or https url.
+func validateURL(rawURL, field string) error {
+	if rawURL == "" {
+		return fmt.Errorf("%w; %s must be set", ErrInvalidSpec, field)
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w; %s is not a valid url; %s", ErrInvalidSpec, field, err.Error())
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w; %s must be an absolute http or https url; found [%s]", ErrInvalidSpec, field, rawURL)
+	}
+
+	return nil
+}
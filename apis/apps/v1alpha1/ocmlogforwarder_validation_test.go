@@ -0,0 +1,690 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// validationTest is a test of ValidateSpec, which modifies a valid component.  An empty wantErr expects the
+// modified component to be valid, otherwise the error is expected to contain wantErr.
+type validationTest struct {
+	name    string
+	modify  func(*OCMLogForwarder)
+	wantErr string
+}
+
+// validComponent returns a component with the minimal valid spec.
+func validComponent() *OCMLogForwarder {
+	return &OCMLogForwarder{
+		Spec: OCMLogForwarderSpec{
+			Ocm: OCMLogForwarderSpecOcm{
+				ClusterId: "abc123",
+				SecretRef: "ocm-token",
+			},
+			Backend: OCMLogForwarderSpecBackend{
+				Type: BackendTypeElasticSearch,
+				ElasticSearch: OCMLogForwarderSpecBackendElasticSearch{
+					Url:       "https://es.example.com:9200",
+					SecretRef: "es-auth",
+				},
+			},
+		},
+	}
+}
+
+func runValidationTests(t *testing.T, tests []validationTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			component := validComponent()
+			tt.modify(component)
+
+			err := component.ValidateSpec()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidSpec) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing [%s]; found [%v]", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSpecElasticSearchIndex(t *testing.T) {
+	elasticSearch := func(modify func(*OCMLogForwarderSpecBackendElasticSearch)) func(*OCMLogForwarder) {
+		return func(component *OCMLogForwarder) {
+			modify(&component.Spec.Backend.ElasticSearch)
+		}
+	}
+
+	runValidationTests(t, []validationTest{
+		{
+			name:   "valid",
+			modify: func(*OCMLogForwarder) {},
+		},
+		{
+			name:   "index pattern with date math",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.IndexPattern = "service-logs-{yyyy.MM.dd}" }),
+		},
+		{
+			name:   "index pattern beginning with date math",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.IndexPattern = "{yyyy-MM}-service-logs" }),
+		},
+		{
+			name:    "index pattern with unsupported date math",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.IndexPattern = "logs-{now/d}" }),
+			wantErr: "contains unsupported date math [{now/d}]",
+		},
+		{
+			name:    "index pattern with unbalanced braces",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.IndexPattern = "logs-{yyyy" }),
+			wantErr: "contains unbalanced braces",
+		},
+		{
+			name:    "index pattern with uppercase",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.IndexPattern = "Logs-{yyyy}" }),
+			wantErr: "must be lowercase",
+		},
+		{
+			name:    "index pattern with invalid characters",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.IndexPattern = "logs*{yyyy}" }),
+			wantErr: "must not contain any of the characters",
+		},
+		{
+			name:    "index pattern with invalid prefix",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.IndexPattern = "_logs-{yyyy}" }),
+			wantErr: "must not begin with '-', '_' or '+'",
+		},
+		{
+			name:    "retention without an index template or data stream",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.Retention = "30d" }),
+			wantErr: "must be true when retention is set",
+		},
+		{
+			name: "retention with a managed index template",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.Retention = "30d"
+				es.ManageIndexTemplate = true
+			}),
+		},
+		{
+			name:    "ilm policy name with a leading underscore",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.ILM.PolicyName = "_policy" }),
+			wantErr: "must not begin with '_' or contain spaces or commas",
+		},
+		{
+			name:    "ilm policy name with a comma",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.ILM.PolicyName = "a,b" }),
+			wantErr: "must not begin with '_' or contain spaces or commas",
+		},
+		{
+			name: "rollover alias",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.ILM.PolicyName = "service-logs"
+				es.ILM.RolloverAlias = "service-logs"
+			}),
+		},
+		{
+			name:    "rollover alias without a policy",
+			modify:  elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) { es.ILM.RolloverAlias = "service-logs" }),
+			wantErr: "ilm.policyName must be set when rolloverAlias is set",
+		},
+		{
+			name: "rollover alias with an index pattern",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.IndexPattern = "logs-{yyyy}"
+				es.ILM.PolicyName = "service-logs"
+				es.ILM.RolloverAlias = "service-logs"
+			}),
+			wantErr: "ilm.rolloverAlias cannot be used with .spec.backend.elasticSearch.indexPattern",
+		},
+		{
+			name: "invalid rollover alias",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.ILM.PolicyName = "service-logs"
+				es.ILM.RolloverAlias = "Service-Logs"
+			}),
+			wantErr: ".ilm.rolloverAlias [Service-Logs] must be lowercase",
+		},
+		{
+			name: "data stream",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.DataStream.Enabled = true
+				es.DataStream.Namespace = "production"
+			}),
+		},
+		{
+			name: "data stream with an index pattern",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.DataStream.Enabled = true
+				es.IndexPattern = "logs-{yyyy}"
+			}),
+			wantErr: "dataStream cannot be used with",
+		},
+		{
+			name: "data stream namespace with a dash",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.DataStream.Enabled = true
+				es.DataStream.Namespace = "prod-east"
+			}),
+			wantErr: "must not contain '-'",
+		},
+		{
+			name: "data stream namespace which is too long",
+			modify: elasticSearch(func(es *OCMLogForwarderSpecBackendElasticSearch) {
+				es.DataStream.Enabled = true
+				es.DataStream.Namespace = strings.Repeat("a", 101)
+			}),
+			wantErr: "must be 100 characters or less",
+		},
+		{
+			name: "data stream with a cluster index",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Ocm.ClusterId = ""
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{{ClusterId: "abc123", Index: "cluster-abc"}}
+				component.Spec.Backend.ElasticSearch.DataStream.Enabled = true
+			},
+			wantErr: ".spec.ocm.clusters[abc123].index cannot be used with .spec.backend.elasticSearch.dataStream",
+		},
+	})
+}
+
+func TestValidateSpecOCMEndpoints(t *testing.T) {
+	runValidationTests(t, []validationTest{
+		{
+			name:   "defaults",
+			modify: func(*OCMLogForwarder) {},
+		},
+		{
+			name: "fedramp endpoints",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Ocm.ApiURL = "https://api.openshiftusgov.com"
+				component.Spec.Ocm.TokenURL = "https://sso.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token"
+			},
+		},
+		{
+			name:    "api url without a scheme",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Ocm.ApiURL = "api.openshift.com" },
+			wantErr: ".spec.ocm.apiURL must be an absolute http or https url",
+		},
+		{
+			name:    "api url with an unsupported scheme",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Ocm.ApiURL = "ftp://api.openshift.com" },
+			wantErr: ".spec.ocm.apiURL must be an absolute http or https url",
+		},
+		{
+			name:    "invalid api url",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Ocm.ApiURL = "https://api.openshift.com:port" },
+			wantErr: ".spec.ocm.apiURL is not a valid url",
+		},
+		{
+			name:    "token url without a host",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Ocm.TokenURL = "https:///token" },
+			wantErr: ".spec.ocm.tokenURL must be an absolute http or https url",
+		},
+	})
+}
+
+func TestOCMEndpointDefaults(t *testing.T) {
+	ocm := &OCMLogForwarderSpecOcm{}
+
+	if ocm.GetAPIURL() != OCMDefaultAPIURL || ocm.GetTokenURL() != OCMDefaultTokenURL {
+		t.Errorf("expected default endpoints; found [%s] [%s]", ocm.GetAPIURL(), ocm.GetTokenURL())
+	}
+
+	ocm.ApiURL, ocm.TokenURL = "https://api.example.com", "https://sso.example.com/token"
+
+	if ocm.GetAPIURL() != ocm.ApiURL || ocm.GetTokenURL() != ocm.TokenURL {
+		t.Errorf("expected configured endpoints; found [%s] [%s]", ocm.GetAPIURL(), ocm.GetTokenURL())
+	}
+}
+
+func TestValidateSpecFilter(t *testing.T) {
+	filter := func(modify func(*OCMLogForwarderSpecFilter)) func(*OCMLogForwarder) {
+		return func(component *OCMLogForwarder) {
+			modify(&component.Spec.Filter)
+		}
+	}
+
+	runValidationTests(t, []validationTest{
+		{
+			name: "valid",
+			modify: filter(func(f *OCMLogForwarderSpecFilter) {
+				f.MinSeverity = SeverityWarning
+				f.IncludeServiceNames = []string{"SREManualAction", "ClusterUpgrade"}
+				f.ExcludeServiceNames = []string{"Billing"}
+				f.SummaryRegex = "^(Cluster|Node) .*"
+				f.MaxAgeMinutes = 60
+			}),
+		},
+		{
+			name:    "unknown severity",
+			modify:  filter(func(f *OCMLogForwarderSpecFilter) { f.MinSeverity = "Critical" }),
+			wantErr: ".spec.filter.minSeverity must be one of",
+		},
+		{
+			name:    "empty included service name",
+			modify:  filter(func(f *OCMLogForwarderSpecFilter) { f.IncludeServiceNames = []string{""} }),
+			wantErr: ".spec.filter.includeServiceNames contains invalid service name []",
+		},
+		{
+			name:    "excluded service name with a comma",
+			modify:  filter(func(f *OCMLogForwarderSpecFilter) { f.ExcludeServiceNames = []string{"a,b"} }),
+			wantErr: ".spec.filter.excludeServiceNames contains invalid service name [a,b]",
+		},
+		{
+			name: "service name both included and excluded",
+			modify: filter(func(f *OCMLogForwarderSpecFilter) {
+				f.IncludeServiceNames = []string{"Billing"}
+				f.ExcludeServiceNames = []string{"Billing"}
+			}),
+			wantErr: "service name [Billing] cannot be in both",
+		},
+		{
+			name:    "invalid summary regex",
+			modify:  filter(func(f *OCMLogForwarderSpecFilter) { f.SummaryRegex = "(unclosed" }),
+			wantErr: ".spec.filter.summaryRegex is not a valid regular expression",
+		},
+		{
+			name:   "unset max age",
+			modify: filter(func(f *OCMLogForwarderSpecFilter) { f.MaxAgeMinutes = 0 }),
+		},
+		{
+			name:    "negative max age",
+			modify:  filter(func(f *OCMLogForwarderSpecFilter) { f.MaxAgeMinutes = -1 }),
+			wantErr: ".spec.filter.maxAgeMinutes must not be negative; found [-1]",
+		},
+	})
+}
+
+func TestValidateSpec(t *testing.T) {
+	backend := func(backend OCMLogForwarderSpecBackend) func(*OCMLogForwarder) {
+		return func(component *OCMLogForwarder) {
+			component.Spec.Backend = backend
+		}
+	}
+
+	runValidationTests(t, []validationTest{
+		{
+			name:    "no cluster",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Ocm.ClusterId = "" },
+			wantErr: "one of .spec.ocm.clusterId or .spec.ocm.clusters must be set",
+		},
+		{
+			name: "cluster id and clusters",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{{ClusterId: "def456"}}
+			},
+			wantErr: "only one of .spec.ocm.clusterId or .spec.ocm.clusters may be set",
+		},
+		{
+			name:    "invalid cluster id",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Ocm.ClusterId = "ABC_123" },
+			wantErr: ".spec.ocm.clusterId [ABC_123] must match regex",
+		},
+		{
+			name:    "invalid ocm secret",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Ocm.SecretRef = "OCM Token" },
+			wantErr: ".spec.ocm.secretRef [OCM Token] is not a valid name",
+		},
+		{
+			name: "clusters",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Ocm.ClusterId = ""
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{
+					{ClusterId: "abc123", SecretRef: "ocm-token-abc", Labels: map[string]string{"cluster": "abc"}},
+					{ClusterId: "def456"},
+				}
+			},
+		},
+		{
+			name: "duplicate clusters",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Ocm.ClusterId = ""
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{{ClusterId: "abc123"}, {ClusterId: "abc123"}}
+			},
+			wantErr: ".spec.ocm.clusters contains duplicate clusterId [abc123]",
+		},
+		{
+			name: "cluster id too long for a label",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Ocm.ClusterId = ""
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{{ClusterId: strings.Repeat("a", 64)}}
+			},
+			wantErr: ".spec.ocm.clusters[0].clusterId [" + strings.Repeat("a", 64) + "] must be no more than 63 characters",
+		},
+		{
+			name: "cluster id too long for the deployment name",
+			modify: func(component *OCMLogForwarder) {
+				component.Name = strings.Repeat("a", 221)
+				component.Spec.Ocm.ClusterId = ""
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{
+					{ClusterId: "abc123"},
+					{ClusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"},
+				}
+			},
+			wantErr: ".spec.ocm.clusters[1].clusterId [22tgckqk9c2ff3jd8ve62p0i2st14vrq] results in a deployment name of 254 characters",
+		},
+		{
+			name: "cluster id at the length of the deployment name",
+			modify: func(component *OCMLogForwarder) {
+				component.Name = strings.Repeat("a", 221)
+				component.Spec.Ocm.ClusterId = ""
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{{ClusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vr"}}
+			},
+		},
+		{
+			name: "invalid cluster label",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Ocm.ClusterId = ""
+				component.Spec.Ocm.Clusters = []OCMLogForwarderSpecOcmCluster{{ClusterId: "abc123", Labels: map[string]string{"a-b": "c"}}}
+			},
+			wantErr: "label name [a-b] from .spec.ocm.clusters[abc123].labels must match regex",
+		},
+		{
+			name: "proxy",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Proxy = OCMLogForwarderSpecProxy{
+					HttpProxy:  "http://proxy.example.com:3128",
+					HttpsProxy: "http://proxy.example.com:3128",
+					NoProxy:    ".cluster.local,.svc",
+				}
+			},
+		},
+		{
+			name:    "invalid proxy url",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Proxy.HttpsProxy = "proxy.example.com:3128" },
+			wantErr: ".spec.proxy.httpsProxy must be an absolute http or https url",
+		},
+		{
+			name:    "no proxy with spaces",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Proxy.NoProxy = ".svc, .cluster.local" },
+			wantErr: ".spec.proxy.noProxy [.svc, .cluster.local] must be a comma-separated list without spaces",
+		},
+		{
+			name:    "image with a tag",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Image = "ghcr.io/scottd018/ocm-log-forwarder:latest" },
+			wantErr: ".spec.image [ghcr.io/scottd018/ocm-log-forwarder:latest] must be an image repository",
+		},
+		{
+			name:   "digest version",
+			modify: func(component *OCMLogForwarder) { component.Spec.Version = "sha256:" + strings.Repeat("a", 64) },
+		},
+		{
+			name:    "invalid digest version",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Version = "sha256:abc" },
+			wantErr: ".spec.version [sha256:abc] must be a tag or a sha256 digest",
+		},
+		{
+			name:    "invalid tag version",
+			modify:  func(component *OCMLogForwarder) { component.Spec.Version = "v1/latest" },
+			wantErr: ".spec.version [v1/latest] must be a tag or a sha256 digest",
+		},
+		{
+			name:    "unset backend type",
+			modify:  backend(OCMLogForwarderSpecBackend{}),
+			wantErr: ".spec.backend.type must be one of",
+		},
+		{
+			name:    "elasticsearch without a url",
+			modify:  backend(OCMLogForwarderSpecBackend{Type: BackendTypeElasticSearch}),
+			wantErr: ".spec.backend.elasticSearch.url must be set",
+		},
+		{
+			name: "elasticsearch reference",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type: BackendTypeElasticSearch,
+				ElasticSearch: OCMLogForwarderSpecBackendElasticSearch{
+					ElasticsearchRef: OCMLogForwarderSpecBackendElasticSearchRef{Name: "logging", Namespace: "elastic"},
+				},
+			}),
+		},
+		{
+			name: "elasticsearch reference without a name",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type: BackendTypeElasticSearch,
+				ElasticSearch: OCMLogForwarderSpecBackendElasticSearch{
+					ElasticsearchRef: OCMLogForwarderSpecBackendElasticSearchRef{Namespace: "elastic"},
+				},
+			}),
+			wantErr: ".spec.backend.elasticSearch.elasticsearchRef.name must be set when namespace is set",
+		},
+		{
+			name: "elasticsearch ca with an unknown kind",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Backend.ElasticSearch.TLS.CARef = OCMLogForwarderSpecBackendElasticSearchTLSCARef{
+					Kind: "Pod",
+					Name: "es-ca",
+					Key:  "ca.crt",
+				}
+			},
+			wantErr: ".spec.backend.elasticSearch.tls.caRef.kind must be one of",
+		},
+		{
+			name: "elasticsearch ca without a key",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Backend.ElasticSearch.TLS.CARef = OCMLogForwarderSpecBackendElasticSearchTLSCARef{
+					Kind: CARefKindConfigMap,
+					Name: "es-ca",
+				}
+			},
+			wantErr: ".spec.backend.elasticSearch.tls.caRef.key must be set",
+		},
+		{
+			name:    "splunk without a url",
+			modify:  backend(OCMLogForwarderSpecBackend{Type: BackendTypeSplunk}),
+			wantErr: ".spec.backend.splunk.url must be set",
+		},
+		{
+			name: "loki with an invalid label",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type: BackendTypeLoki,
+				Loki: OCMLogForwarderSpecBackendLoki{
+					Url:    "http://loki.example.com/loki/api/v1/push",
+					Labels: map[string]string{"0source": "ocm"},
+				},
+			}),
+			wantErr: "label name [0source] from .spec.backend.loki.labels must match regex",
+		},
+		{
+			name:    "kafka without bootstrap servers",
+			modify:  backend(OCMLogForwarderSpecBackend{Type: BackendTypeKafka}),
+			wantErr: ".spec.backend.kafka.bootstrapServers must be set",
+		},
+		{
+			name: "kafka bootstrap server without a port",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type:  BackendTypeKafka,
+				Kafka: OCMLogForwarderSpecBackendKafka{BootstrapServers: []string{"kafka"}, Topic: "logs"},
+			}),
+			wantErr: ".spec.backend.kafka.bootstrapServers must be in host:port form; found [kafka]",
+		},
+		{
+			name: "kafka bootstrap server with an invalid port",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type:  BackendTypeKafka,
+				Kafka: OCMLogForwarderSpecBackendKafka{BootstrapServers: []string{"kafka:70000"}, Topic: "logs"},
+			}),
+			wantErr: ".spec.backend.kafka.bootstrapServers has an invalid port; found [kafka:70000]",
+		},
+		{
+			name: "kafka with an invalid topic",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type:  BackendTypeKafka,
+				Kafka: OCMLogForwarderSpecBackendKafka{BootstrapServers: []string{"kafka:9092"}, Topic: "service logs"},
+			}),
+			wantErr: ".spec.backend.kafka.topic [service logs] must match regex",
+		},
+		{
+			name:    "s3 without a bucket",
+			modify:  backend(OCMLogForwarderSpecBackend{Type: BackendTypeS3}),
+			wantErr: ".spec.backend.s3.bucket must be set",
+		},
+		{
+			name: "s3 with an invalid bucket",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type: BackendTypeS3,
+				S3:   OCMLogForwarderSpecBackendS3{Bucket: "Service_Logs"},
+			}),
+			wantErr: ".spec.backend.s3.bucket [Service_Logs] must match regex",
+		},
+		{
+			name: "s3 prefix with a leading slash",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type: BackendTypeS3,
+				S3:   OCMLogForwarderSpecBackendS3{Bucket: "service-logs", Prefix: "/logs"},
+			}),
+			wantErr: ".spec.backend.s3.prefix [/logs] must not begin with '/'",
+		},
+		{
+			name: "s3 prefix with an unsupported placeholder",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type: BackendTypeS3,
+				S3:   OCMLogForwarderSpecBackendS3{Bucket: "service-logs", Prefix: "{clusterId}/{hour}"},
+			}),
+			wantErr: "contains unsupported placeholder [{hour}]",
+		},
+		{
+			name: "s3 prefix with unbalanced braces",
+			modify: backend(OCMLogForwarderSpecBackend{
+				Type: BackendTypeS3,
+				S3:   OCMLogForwarderSpecBackendS3{Bucket: "service-logs", Prefix: "{clusterId}/{year"},
+			}),
+			wantErr: ".spec.backend.s3.prefix [{clusterId}/{year] contains unbalanced braces",
+		},
+		{
+			name: "named backends",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Backends = []OCMLogForwarderSpecNamedBackend{
+					{Name: "primary", OCMLogForwarderSpecBackend: component.Spec.Backend},
+					{
+						Name: "archive",
+						OCMLogForwarderSpecBackend: OCMLogForwarderSpecBackend{
+							Type: BackendTypeS3,
+							S3:   OCMLogForwarderSpecBackendS3{Bucket: "service-logs"},
+						},
+					},
+				}
+			},
+		},
+		{
+			name: "named backend without a name",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Backends = []OCMLogForwarderSpecNamedBackend{{OCMLogForwarderSpecBackend: component.Spec.Backend}}
+			},
+			wantErr: ".spec.backends[0].name must be set",
+		},
+		{
+			name: "duplicate named backends",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Backends = []OCMLogForwarderSpecNamedBackend{
+					{Name: "primary", OCMLogForwarderSpecBackend: component.Spec.Backend},
+					{Name: "primary", OCMLogForwarderSpecBackend: component.Spec.Backend},
+				}
+			},
+			wantErr: ".spec.backends contains duplicate name [primary]",
+		},
+		{
+			name: "invalid named backend",
+			modify: func(component *OCMLogForwarder) {
+				component.Spec.Backends = []OCMLogForwarderSpecNamedBackend{
+					{Name: "archive", OCMLogForwarderSpecBackend: OCMLogForwarderSpecBackend{Type: BackendTypeS3}},
+				}
+			},
+			wantErr: ".spec.backends[archive].s3.bucket must be set",
+		},
+	})
+}
+
+func TestSetDefaults(t *testing.T) {
+	spec := &OCMLogForwarderSpec{}
+	spec.SetDefaults()
+
+	expectedOcm := OCMLogForwarderSpecOcm{
+		SecretRef:           DefaultOCMSecretRef,
+		AuthType:            OCMAuthTypeOfflineToken,
+		PollInternalMinutes: DefaultOCMPollIntervalMinutes,
+		ApiURL:              OCMDefaultAPIURL,
+		TokenURL:            OCMDefaultTokenURL,
+	}
+
+	if !reflect.DeepEqual(spec.Ocm, expectedOcm) {
+		t.Errorf("expected the default ocm configuration; found %+v", spec.Ocm)
+	}
+
+	expected := OCMLogForwarderSpecBackendElasticSearch{
+		Url:       DefaultElasticSearchURL,
+		SecretRef: DefaultElasticSearchSecretRef,
+		AuthType:  ElasticSearchAuthTypeBasic,
+		Index:     DefaultElasticSearchIndex,
+	}
+
+	if spec.Backend.Type != BackendTypeElasticSearch || spec.Backend.ElasticSearch != expected {
+		t.Errorf("expected the default elasticsearch backend; found %+v", spec.Backend)
+	}
+
+	spec = &OCMLogForwarderSpec{
+		Backends: []OCMLogForwarderSpecNamedBackend{
+			{Name: "archive", OCMLogForwarderSpecBackend: OCMLogForwarderSpecBackend{Type: BackendTypeS3}},
+			{
+				Name: "eck",
+				OCMLogForwarderSpecBackend: OCMLogForwarderSpecBackend{
+					ElasticSearch: OCMLogForwarderSpecBackendElasticSearch{
+						ElasticsearchRef: OCMLogForwarderSpecBackendElasticSearchRef{Name: "logging"},
+					},
+				},
+			},
+		},
+		Ocm: OCMLogForwarderSpecOcm{
+			SecretRef:           "ocm-credentials",
+			AuthType:            OCMAuthTypeServiceAccount,
+			PollInternalMinutes: 15,
+			ApiURL:              "https://api.stage.openshift.com",
+			TokenURL:            "https://sso.example.com/token",
+		},
+	}
+	expectedOcm = spec.Ocm
+	spec.SetDefaults()
+
+	if !reflect.DeepEqual(spec.Ocm, expectedOcm) {
+		t.Errorf("expected the ocm configuration to be left unchanged; found %+v", spec.Ocm)
+	}
+
+	if spec.Backend.Type != "" {
+		t.Errorf("expected the unused backend to be left unset; found %+v", spec.Backend)
+	}
+
+	if spec.Backends[0].Type != BackendTypeS3 || spec.Backends[0].ElasticSearch.Url != "" {
+		t.Errorf("expected the s3 backend to be left unchanged; found %+v", spec.Backends[0])
+	}
+
+	if spec.Backends[1].Type != BackendTypeElasticSearch || spec.Backends[1].ElasticSearch.Url != "" {
+		t.Errorf("expected the referenced elasticsearch backend to be resolved from the reference; found %+v", spec.Backends[1])
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+//+kubebuilder:webhook:path=/validate-apps-dustinscott-io-v1alpha1-ocmlogforwarder,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.dustinscott.io,resources=ocmlogforwarders,verbs=create;update,versions=v1alpha1,name=vocmlogforwarder.kb.io,admissionReviewVersions=v1,matchPolicy=Equivalent
+
+// SetupWebhookWithManager registers the validating webhook for OCMLogForwarder with the manager.  As the
+// webhook matches equivalent requests, objects of other versions are converted to this version and validated
+// as well.
+func (component *OCMLogForwarder) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(component).
+		WithValidator(&ocmLogForwarderValidator{reader: mgr.GetClient()}).
+		Complete()
+}
+
+// ocmLogForwarderValidator validates OCMLogForwarder objects on admission, so that specs which can not be
+// reconciled are rejected rather than failing in a later phase.
+type ocmLogForwarderValidator struct {
+	reader client.Reader
+}
+
+var _ webhook.CustomValidator = &ocmLogForwarderValidator{}
+
+// ValidateCreate validates an OCMLogForwarder which is being created.
+func (validator *ocmLogForwarderValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	component, ok := obj.(*OCMLogForwarder)
+	if !ok {
+		return fmt.Errorf("%w, unexpected object type %T", ErrUnableToConvertOCMLogForwarder, obj)
+	}
+
+	return validator.validate(ctx, component)
+}
+
+// ValidateUpdate validates an OCMLogForwarder which is being updated.  Updates which do not change the spec,
+// such as those to the metadata of an object which is being deleted, are not validated so that objects which
+// were admitted prior to a validation rule are not blocked.
+func (validator *ocmLogForwarderValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	previous, ok := oldObj.(*OCMLogForwarder)
+	if !ok {
+		return fmt.Errorf("%w, unexpected object type %T", ErrUnableToConvertOCMLogForwarder, oldObj)
+	}
+
+	component, ok := newObj.(*OCMLogForwarder)
+	if !ok {
+		return fmt.Errorf("%w, unexpected object type %T", ErrUnableToConvertOCMLogForwarder, newObj)
+	}
+
+	if equality.Semantic.DeepEqual(previous.Spec, component.Spec) {
+		return nil
+	}
+
+	return validator.validate(ctx, component)
+}
+
+// ValidateDelete validates an OCMLogForwarder which is being deleted.  Deletion is always allowed.
+func (validator *ocmLogForwarderValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// validate validates the spec of a component, and that no other OCMLogForwarder in the namespace of the
+// component, nor a ClusterOCMLogForwarder which deploys its forwarder to the namespace of the component, forwards
+// the service logs of the same cluster to the same index.
+func (validator *ocmLogForwarderValidator) validate(ctx context.Context, component *OCMLogForwarder) error {
+	if err := component.ValidateSpec(); err != nil {
+		return err
+	}
+
+	targets := component.getIndexTargets()
+	if len(targets) == 0 {
+		return nil
+	}
+
+	others, err := validator.listIndexTargets(ctx, component)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range others {
+		for _, key := range sortedKeys(targets) {
+			if _, found := other.targets[key]; found {
+				return fmt.Errorf(
+					"%w; %s, which is already forwarded by %s [%s]",
+					ErrInvalidSpec,
+					targets[key],
+					other.kind,
+					other.name,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// indexTargets are the index targets of a forwarder, as returned by getIndexTargets, along with the kind and
+// name of the forwarder.
+type indexTargets struct {
+	kind    string
+	name    string
+	targets map[string]string
+}
+
+// listIndexTargets returns the index targets of the forwarders, other than the component, which are deployed to
+// the namespace of the component and are not being deleted, including those of the ClusterOCMLogForwarder
+// objects which deploy their forwarder to the namespace of the component.
+func (validator *ocmLogForwarderValidator) listIndexTargets(ctx context.Context, component *OCMLogForwarder) ([]indexTargets, error) {
+	forwarders := &OCMLogForwarderList{}
+	if err := validator.reader.List(ctx, forwarders, client.InNamespace(component.Namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list OCMLogForwarder objects in namespace %s, %w", component.Namespace, err)
+	}
+
+	clusterForwarders := &ClusterOCMLogForwarderList{}
+	if err := validator.reader.List(ctx, clusterForwarders); err != nil {
+		return nil, fmt.Errorf("unable to list ClusterOCMLogForwarder objects, %w", err)
+	}
+
+	others := []indexTargets{}
+
+	for i := range forwarders.Items {
+		other := &forwarders.Items[i]
+
+		if other.Name == component.Name || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		others = append(others, indexTargets{
+			kind:    "OCMLogForwarder",
+			name:    other.Name,
+			targets: other.getIndexTargets(),
+		})
+	}
+
+	for i := range clusterForwarders.Items {
+		other := &clusterForwarders.Items[i]
+
+		if other.GetTargetNamespace() != component.Namespace || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		others = append(others, indexTargets{
+			kind:    "ClusterOCMLogForwarder",
+			name:    other.Name,
+			targets: other.ToOCMLogForwarder().getIndexTargets(),
+		})
+	}
+
+	return others, nil
+}
+
+// getIndexTargets returns a description of each index which the service logs of each cluster are forwarded to
+// by the 'elasticsearch' and 'splunk' backends of a component, keyed by the cluster, backend and index.
+func (component *OCMLogForwarder) getIndexTargets() map[string]string {
+	targets := map[string]string{}
+
+	for _, cluster := range component.GetClusters() {
+		for _, backend := range component.GetBackends() {
+			var endpoint, index string
+
+			switch backend.Type {
+			case BackendTypeElasticSearch:
+				endpoint, index = backend.ElasticSearch.Url, backend.ElasticSearch.getWriteIndex()
+
+				if ref := backend.ElasticSearch.ElasticsearchRef; ref.Name != "" {
+					endpoint = "elasticsearch " + component.GetElasticsearchRefNamespace(ref) + "/" + ref.Name
+				}
+			case BackendTypeSplunk:
+				endpoint, index = backend.Splunk.Url, backend.Splunk.Index
+			default:
+				continue
+			}
+
+			if cluster.Index != "" {
+				index = cluster.Index
+			}
+
+			key := strings.Join([]string{cluster.ClusterId, backend.Type, endpoint, index}, "|")
+			targets[key] = fmt.Sprintf(
+				"%s forwards cluster [%s] to %s index [%s] at [%s]",
+				backend.GetFieldPath(),
+				cluster.ClusterId,
+				backend.Type,
+				index,
+				endpoint,
+			)
+		}
+	}
+
+	return targets
+}
+
+// getWriteIndex returns the data stream, alias, index pattern or index to which the elasticsearch backend
+// writes service logs.
+func (elasticSearch *OCMLogForwarderSpecBackendElasticSearch) getWriteIndex() string {
+	switch {
+	case elasticSearch.DataStream.Enabled:
+		return elasticSearch.DataStream.GetName()
+	case elasticSearch.ILM.RolloverAlias != "":
+		return elasticSearch.ILM.RolloverAlias
+	case elasticSearch.IndexPattern != "":
+		return elasticSearch.IndexPattern
+	default:
+		return elasticSearch.Index
+	}
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	appsv1beta1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1"
+)
+
+var _ = Describe("OCMLogForwarder validating webhook", func() {
+	var namespace string
+
+	// newForwarder returns a valid OCMLogForwarder in the namespace of the current spec.
+	newForwarder := func(name string) *OCMLogForwarder {
+		return &OCMLogForwarder{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: OCMLogForwarderSpec{
+				Ocm: OCMLogForwarderSpecOcm{ClusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"},
+				Backend: OCMLogForwarderSpecBackend{
+					Type: BackendTypeElasticSearch,
+					ElasticSearch: OCMLogForwarderSpecBackendElasticSearch{
+						Url:   "https://elasticsearch.example.com:9200",
+						Index: "ocm_service_logs",
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "webhook-"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		namespace = ns.Name
+	})
+
+	It("admits a valid spec", func() {
+		Expect(k8sClient.Create(ctx, newForwarder("valid"))).To(Succeed())
+	})
+
+	It("admits a spec with only the required fields and defaults its backend", func() {
+		// the manifest is created as is, rather than from an OCMLogForwarder, which always has a backend.
+		sample := &unstructured.Unstructured{}
+		Expect(yaml.Unmarshal([]byte(`apiVersion: apps.dustinscott.io/v1alpha1
+kind: OCMLogForwarder
+metadata:
+  name: required
+spec:
+  ocm:
+    clusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"
+`), &sample.Object)).To(Succeed())
+		sample.SetNamespace(namespace)
+
+		Expect(k8sClient.Create(ctx, sample)).To(Succeed())
+
+		forwarder := &OCMLogForwarder{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sample), forwarder)).To(Succeed())
+		Expect(forwarder.Spec.Backend.Type).To(Equal(BackendTypeElasticSearch))
+		Expect(forwarder.Spec.Backend.ElasticSearch.Url).To(Equal(DefaultElasticSearchURL))
+		Expect(forwarder.Spec.Backend.ElasticSearch.SecretRef).To(Equal(DefaultElasticSearchSecretRef))
+	})
+
+	DescribeTable("rejects an invalid spec",
+		func(mutate func(*OCMLogForwarder), message string) {
+			forwarder := newForwarder("invalid")
+			mutate(forwarder)
+
+			err := k8sClient.Create(ctx, forwarder)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("with an invalid cluster id",
+			func(forwarder *OCMLogForwarder) { forwarder.Spec.Ocm.ClusterId = "Not_A_Cluster" },
+			".spec.ocm.clusterId [Not_A_Cluster] must match regex",
+		),
+		Entry("with a backend type without its configuration",
+			func(forwarder *OCMLogForwarder) { forwarder.Spec.Backend.Type = BackendTypeLoki },
+			".spec.backend.loki.url must be set",
+		),
+		Entry("with a secret reference which is not a valid name",
+			func(forwarder *OCMLogForwarder) { forwarder.Spec.Ocm.SecretRef = "OCM_Token" },
+			".spec.ocm.secretRef [OCM_Token] is not a valid name",
+		),
+		Entry("with an elasticsearch url which can not be parsed",
+			func(forwarder *OCMLogForwarder) {
+				forwarder.Spec.Backend.ElasticSearch.Url = "https://elastic search:9200"
+			},
+			".spec.backend.elasticSearch.url is not a valid url",
+		),
+	)
+
+	It("rejects a second forwarder of the same cluster to the same index", func() {
+		Expect(k8sClient.Create(ctx, newForwarder("first"))).To(Succeed())
+
+		err := k8sClient.Create(ctx, newForwarder("second"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("already forwarded by OCMLogForwarder [first]"))
+
+		other := newForwarder("other-index")
+		other.Spec.Backend.ElasticSearch.Index = "other_service_logs"
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+	})
+
+	It("rejects an update which forwards to the index of another forwarder", func() {
+		Expect(k8sClient.Create(ctx, newForwarder("first"))).To(Succeed())
+
+		other := newForwarder("other-index")
+		other.Spec.Backend.ElasticSearch.Index = "other_service_logs"
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+
+		other.Spec.Backend.ElasticSearch.Index = "ocm_service_logs"
+		err := k8sClient.Update(ctx, other)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("already forwarded by OCMLogForwarder [first]"))
+	})
+
+	It("rejects a forwarder of the same cluster to the index of a cluster forwarder in the namespace", func() {
+		clusterForwarder := &ClusterOCMLogForwarder{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec: ClusterOCMLogForwarderSpec{
+				TargetNamespace:     namespace,
+				OCMLogForwarderSpec: newForwarder(namespace).Spec,
+			},
+		}
+		Expect(k8sClient.Create(ctx, clusterForwarder)).To(Succeed())
+
+		err := k8sClient.Create(ctx, newForwarder("first"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("already forwarded by ClusterOCMLogForwarder [" + namespace + "]"))
+
+		other := newForwarder("other-index")
+		other.Spec.Backend.ElasticSearch.Index = "other_service_logs"
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+	})
+
+	It("validates objects which are created using another version", func() {
+		forwarder := &appsv1beta1.OCMLogForwarder{
+			ObjectMeta: metav1.ObjectMeta{Name: "hub", Namespace: namespace},
+			Spec: appsv1beta1.OCMLogForwarderSpec{
+				Ocm: appsv1beta1.OCMLogForwarderSpecOcm{ClusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"},
+				Backends: []appsv1beta1.OCMLogForwarderSpecBackend{
+					{
+						Name: appsv1beta1.DefaultBackendName,
+						Type: appsv1beta1.BackendTypeElasticSearch,
+						ElasticSearch: &appsv1beta1.OCMLogForwarderSpecBackendElasticSearch{
+							Url:       "https://elasticsearch.example.com:9200",
+							SecretRef: "Elastic_Auth",
+						},
+					},
+				},
+			},
+		}
+
+		err := k8sClient.Create(ctx, forwarder)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(".spec.backend.elasticSearch.secretRef [Elastic_Auth] is not a valid name"))
+	})
+})
@@ -26,12 +26,87 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOCMLogForwarder) DeepCopyInto(out *ClusterOCMLogForwarder) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOCMLogForwarder.
+func (in *ClusterOCMLogForwarder) DeepCopy() *ClusterOCMLogForwarder {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOCMLogForwarder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOCMLogForwarder) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOCMLogForwarderList) DeepCopyInto(out *ClusterOCMLogForwarderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterOCMLogForwarder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOCMLogForwarderList.
+func (in *ClusterOCMLogForwarderList) DeepCopy() *ClusterOCMLogForwarderList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOCMLogForwarderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOCMLogForwarderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOCMLogForwarderSpec) DeepCopyInto(out *ClusterOCMLogForwarderSpec) {
+	*out = *in
+	in.OCMLogForwarderSpec.DeepCopyInto(&out.OCMLogForwarderSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOCMLogForwarderSpec.
+func (in *ClusterOCMLogForwarderSpec) DeepCopy() *ClusterOCMLogForwarderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOCMLogForwarderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMLogForwarder) DeepCopyInto(out *OCMLogForwarder) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -53,6 +128,22 @@ func (in *OCMLogForwarder) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderCondition) DeepCopyInto(out *OCMLogForwarderCondition) {
+	*out = *in
+	out.PhaseCondition = in.PhaseCondition
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderCondition.
+func (in *OCMLogForwarderCondition) DeepCopy() *OCMLogForwarderCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMLogForwarderList) DeepCopyInto(out *OCMLogForwarderList) {
 	*out = *in
@@ -88,8 +179,17 @@ func (in *OCMLogForwarderList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMLogForwarderSpec) DeepCopyInto(out *OCMLogForwarderSpec) {
 	*out = *in
-	out.Ocm = in.Ocm
-	out.Backend = in.Backend
+	in.Ocm.DeepCopyInto(&out.Ocm)
+	in.Backend.DeepCopyInto(&out.Backend)
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]OCMLogForwarderSpecNamedBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Proxy = in.Proxy
+	in.Filter.DeepCopyInto(&out.Filter)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpec.
@@ -106,6 +206,10 @@ func (in *OCMLogForwarderSpec) DeepCopy() *OCMLogForwarderSpec {
 func (in *OCMLogForwarderSpecBackend) DeepCopyInto(out *OCMLogForwarderSpecBackend) {
 	*out = *in
 	out.ElasticSearch = in.ElasticSearch
+	out.Splunk = in.Splunk
+	in.Loki.DeepCopyInto(&out.Loki)
+	in.Kafka.DeepCopyInto(&out.Kafka)
+	out.S3 = in.S3
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackend.
@@ -121,6 +225,10 @@ func (in *OCMLogForwarderSpecBackend) DeepCopy() *OCMLogForwarderSpecBackend {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMLogForwarderSpecBackendElasticSearch) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearch) {
 	*out = *in
+	out.ILM = in.ILM
+	out.DataStream = in.DataStream
+	out.TLS = in.TLS
+	out.ElasticsearchRef = in.ElasticsearchRef
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearch.
@@ -133,9 +241,205 @@ func (in *OCMLogForwarderSpecBackendElasticSearch) DeepCopy() *OCMLogForwarderSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchDataStream) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchDataStream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchDataStream.
+func (in *OCMLogForwarderSpecBackendElasticSearchDataStream) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchDataStream {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchDataStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchILM) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchILM) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchILM.
+func (in *OCMLogForwarderSpecBackendElasticSearchILM) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchILM {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchILM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchRef) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchRef.
+func (in *OCMLogForwarderSpecBackendElasticSearchRef) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchRef {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLS) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchTLS) {
+	*out = *in
+	out.CARef = in.CARef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchTLS.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLS) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLSCARef) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchTLSCARef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchTLSCARef.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLSCARef) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchTLSCARef {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchTLSCARef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendKafka) DeepCopyInto(out *OCMLogForwarderSpecBackendKafka) {
+	*out = *in
+	if in.BootstrapServers != nil {
+		in, out := &in.BootstrapServers, &out.BootstrapServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendKafka.
+func (in *OCMLogForwarderSpecBackendKafka) DeepCopy() *OCMLogForwarderSpecBackendKafka {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendKafka)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendLoki) DeepCopyInto(out *OCMLogForwarderSpecBackendLoki) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendLoki.
+func (in *OCMLogForwarderSpecBackendLoki) DeepCopy() *OCMLogForwarderSpecBackendLoki {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendLoki)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendS3) DeepCopyInto(out *OCMLogForwarderSpecBackendS3) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendS3.
+func (in *OCMLogForwarderSpecBackendS3) DeepCopy() *OCMLogForwarderSpecBackendS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendSplunk) DeepCopyInto(out *OCMLogForwarderSpecBackendSplunk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendSplunk.
+func (in *OCMLogForwarderSpecBackendSplunk) DeepCopy() *OCMLogForwarderSpecBackendSplunk {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendSplunk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecFilter) DeepCopyInto(out *OCMLogForwarderSpecFilter) {
+	*out = *in
+	if in.IncludeServiceNames != nil {
+		in, out := &in.IncludeServiceNames, &out.IncludeServiceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeServiceNames != nil {
+		in, out := &in.ExcludeServiceNames, &out.ExcludeServiceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecFilter.
+func (in *OCMLogForwarderSpecFilter) DeepCopy() *OCMLogForwarderSpecFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecNamedBackend) DeepCopyInto(out *OCMLogForwarderSpecNamedBackend) {
+	*out = *in
+	in.OCMLogForwarderSpecBackend.DeepCopyInto(&out.OCMLogForwarderSpecBackend)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecNamedBackend.
+func (in *OCMLogForwarderSpecNamedBackend) DeepCopy() *OCMLogForwarderSpecNamedBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecNamedBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMLogForwarderSpecOcm) DeepCopyInto(out *OCMLogForwarderSpecOcm) {
 	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]OCMLogForwarderSpecOcmCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecOcm.
@@ -148,16 +452,53 @@ func (in *OCMLogForwarderSpecOcm) DeepCopy() *OCMLogForwarderSpecOcm {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecOcmCluster) DeepCopyInto(out *OCMLogForwarderSpecOcmCluster) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecOcmCluster.
+func (in *OCMLogForwarderSpecOcmCluster) DeepCopy() *OCMLogForwarderSpecOcmCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecOcmCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecProxy) DeepCopyInto(out *OCMLogForwarderSpecProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecProxy.
+func (in *OCMLogForwarderSpecProxy) DeepCopy() *OCMLogForwarderSpecProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMLogForwarderStatus) DeepCopyInto(out *OCMLogForwarderStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]*status.PhaseCondition, len(*in))
+		*out = make([]*OCMLogForwarderCondition, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(status.PhaseCondition)
+				*out = new(OCMLogForwarderCondition)
 				**out = **in
 			}
 		}
@@ -173,6 +514,22 @@ func (in *OCMLogForwarderStatus) DeepCopyInto(out *OCMLogForwarderStatus) {
 			}
 		}
 	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]OCMLogForwarderStatusCluster, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageResolutionFailureTime != nil {
+		in, out := &in.ImageResolutionFailureTime, &out.ImageResolutionFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SuspendedReplicas != nil {
+		in, out := &in.SuspendedReplicas, &out.SuspendedReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderStatus.
@@ -184,3 +541,18 @@ func (in *OCMLogForwarderStatus) DeepCopy() *OCMLogForwarderStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderStatusCluster) DeepCopyInto(out *OCMLogForwarderStatusCluster) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderStatusCluster.
+func (in *OCMLogForwarderStatusCluster) DeepCopy() *OCMLogForwarderStatusCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderStatusCluster)
+	in.DeepCopyInto(out)
+	return out
+}
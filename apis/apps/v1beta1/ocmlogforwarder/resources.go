@@ -0,0 +1,174 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocmlogforwarder
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	v1alpha1ocmlogforwarder "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+	appsv1beta1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1"
+)
+
+// sampleOCMLogForwarder is a sample containing all fields
+const sampleOCMLogForwarder = `apiVersion: apps.dustinscott.io/v1beta1
+kind: OCMLogForwarder
+metadata:
+  name: ocmlogforwarder-sample
+  namespace: default
+spec:
+  ocm:
+    secretRef: "ocm-token"
+    authType: "offlineToken"
+    clusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"
+    pollInterval: "5m"
+    apiURL: "https://api.openshift.com"
+    tokenURL: "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+  backends:
+    - name: "default"
+      type: "ElasticSearch"
+      elasticSearch:
+        secretRef: "elastic-auth"
+        url: "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200"
+        authType: "basic"
+        index: "ocm_service_logs"
+        indexPattern: ""
+        ilm:
+          policyName: ""
+          rolloverAlias: ""
+        dataStream:
+          enabled: false
+          namespace: "default"
+        manageIndexTemplate: false
+        retention: ""
+        tls:
+          caRef:
+            kind: "Secret"
+            name: ""
+            key: "ca.crt"
+          clientCertSecretRef: ""
+          insecureSkipVerify: false
+        elasticsearchRef:
+          name: ""
+          namespace: ""
+  proxy:
+    httpProxy: ""
+    httpsProxy: ""
+    noProxy: ""
+    inheritClusterProxy: false
+    injectTrustedCABundle: false
+  filter:
+    minSeverity: "Info"
+    includeServiceNames: []
+    excludeServiceNames: []
+    summaryRegex: ""
+    maxAgeMinutes: 1440
+  version: "latest"
+  image: "ghcr.io/scottd018/ocm-log-forwarder"
+  debug: false
+  suspend: false
+`
+
+// sampleOCMLogForwarderRequired is a sample containing only required fields
+const sampleOCMLogForwarderRequired = `apiVersion: apps.dustinscott.io/v1beta1
+kind: OCMLogForwarder
+metadata:
+  name: ocmlogforwarder-sample
+  namespace: default
+spec:
+  ocm:
+    clusterId: "22tgckqk9c2ff3jd8ve62p0i2st14vrq"
+  backends:
+    - name: "default"
+      type: "ElasticSearch"
+      elasticSearch: {}
+`
+
+// Sample returns the sample manifest for this custom resource.
+func Sample(requiredOnly bool) string {
+	if requiredOnly {
+		return sampleOCMLogForwarderRequired
+	}
+
+	return sampleOCMLogForwarder
+}
+
+// Generate returns the child resources that are associated with this workload given
+// appropriate structured inputs.  The child resources are generated from the workload
+// converted to v1alpha1, which is the version that is reconciled by the controller.
+func Generate(
+	workloadObj appsv1beta1.OCMLogForwarder,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	converted, err := convert(&workloadObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1alpha1ocmlogforwarder.Generate(*converted, reconciler, req)
+}
+
+// convert converts a workload to v1alpha1, which is the version that is reconciled by the controller.
+func convert(workloadObj *appsv1beta1.OCMLogForwarder) (*appsv1alpha1.OCMLogForwarder, error) {
+	converted := &appsv1alpha1.OCMLogForwarder{}
+	if err := converted.ConvertFrom(workloadObj); err != nil {
+		return nil, fmt.Errorf("unable to convert workload to %s, %w", appsv1alpha1.GroupVersion, err)
+	}
+
+	converted.SetGroupVersionKind(converted.GetWorkloadGVK())
+
+	return converted, nil
+}
+
+// GenerateForCLI returns the child resources that are associated with this workload given
+// appropriate YAML manifest files.
+func GenerateForCLI(workloadFile []byte) ([]client.Object, error) {
+	var workloadObj appsv1beta1.OCMLogForwarder
+	if err := yaml.Unmarshal(workloadFile, &workloadObj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+	}
+
+	if gvk := workloadObj.GroupVersionKind(); gvk != workloadObj.GetWorkloadGVK() {
+		return nil, fmt.Errorf(
+			"error validating workload yaml, %w, expected resource of kind: '%s', with group '%s' and version '%s'; "+
+				"found resource of kind '%s', with group '%s' and version '%s'",
+			workload.ErrInvalidWorkload,
+			workloadObj.GetWorkloadGVK().Kind,
+			workloadObj.GetWorkloadGVK().Group,
+			workloadObj.GetWorkloadGVK().Version,
+			gvk.Kind,
+			gvk.Group,
+			gvk.Version,
+		)
+	}
+
+	converted, err := convert(&workloadObj)
+	if err != nil {
+		return nil, err
+	}
+
+	// the defaults of the custom resource definition are not applied to a manifest which is read from a file.
+	converted.Spec.SetDefaults()
+
+	return v1alpha1ocmlogforwarder.Generate(*converted, nil, nil)
+}
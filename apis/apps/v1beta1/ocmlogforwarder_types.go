@@ -0,0 +1,802 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// backend types which are supported by the log forwarder.  Each backend type matches the name of the member
+// of the backend union which configures it.
+const (
+	BackendTypeElasticSearch = "ElasticSearch"
+	BackendTypeSplunk        = "Splunk"
+	BackendTypeLoki          = "Loki"
+	BackendTypeKafka         = "Kafka"
+	BackendTypeS3            = "S3"
+)
+
+// DefaultBackendName is the name of the backend which is converted from the single .spec.backend field of
+// previous API versions.
+const DefaultBackendName = "default"
+
+// OCMLogForwarderSpec defines the desired state of OCMLogForwarder.
+type OCMLogForwarderSpec struct {
+	// +kubebuilder:validation:Optional
+	Ocm OCMLogForwarderSpecOcm `json:"ocm,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	//  Backends where logs are sent and stored.  Each service log is sent to every backend in the list.
+	//
+	Backends []OCMLogForwarderSpecBackend `json:"backends"`
+
+	// +kubebuilder:validation:Optional
+	//  HTTP(S) proxy configuration for the forwarder, for clusters which egress through a proxy.
+	//
+	Proxy OCMLogForwarderSpecProxy `json:"proxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Filter which selects the service logs that are forwarded to the backends.  When unset, all service
+	//  logs are forwarded.
+	//
+	Filter OCMLogForwarderSpecFilter `json:"filter,omitempty"`
+
+	// +kubebuilder:default="latest"
+	// +kubebuilder:validation:Optional
+	// (Default: "latest")
+	//  OCM Log Forwarder version to use.  Any of the tags from the ocm-log-forwarder GitHub
+	//  repo are supported here, as is an image digest such as 'sha256:...'.  A tag is resolved to a
+	//  digest when the forwarder is deployed, so that a moving tag such as 'latest' is pinned.
+	//
+	Version string `json:"version,omitempty"`
+
+	// +kubebuilder:default="ghcr.io/scottd018/ocm-log-forwarder"
+	// +kubebuilder:validation:Optional
+	// (Default: "ghcr.io/scottd018/ocm-log-forwarder")
+	//  Image repository of the OCM Log Forwarder, without a tag.  The requested version is resolved to a
+	//  digest from the registry of this repository, so that every forwarder runs the same build.  Set this
+	//  to use a mirror of the image in another registry.
+	//
+	Image string `json:"image,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Enable debug logging on the log forwarder.
+	//
+	Debug bool `json:"debug,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Suspend forwarding without deleting this resource.  The forwarder deployments are scaled to zero
+	//  while their RBAC is left intact, and their previous replica counts are restored when forwarding
+	//  is resumed.
+	//
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+type OCMLogForwarderSpecOcm struct {
+	// +kubebuilder:default="ocm-token"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm-token")
+	//  The secret should contain the OCM credentials.  See .spec.ocm.authType for more information on
+	//  secret requirements.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:default="offlineToken"
+	// +kubebuilder:validation:Optional
+	// (Default: "offlineToken")
+	//  +kubebuilder:validation:Enum=offlineToken;serviceAccount
+	//  OCM authentication type to use.
+	//
+	//  * 'offlineToken': The secret from .spec.ocm.secretRef should contain the OCM JSON token obtained
+	//  from OpenShift Cluster Manager.  It should have a single key/value pair with the form of
+	//  clusterId=ocmTokenJson.  The clusterId should match the .spec.ocm.clusterId field, while the
+	//  ocmTokenJson value should be a string form of the token obtained from OCM.
+	//
+	//  * 'serviceAccount': The secret from .spec.ocm.secretRef should contain the client id and client
+	//  secret of a Red Hat service account at keys 'client_id' and 'client_secret'.
+	//
+	AuthType string `json:"authType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Cluster ID of the cluster to forward logs from.  This Cluster ID can be found in the OCM Console
+	//  as part of the URL when selecting the cluster.  It shows up in a form such as
+	//  '22tgckqk9c2ff3jd8ve62p0i2st14vrq'.  Either this field or .spec.ocm.clusters must be set.
+	//
+	ClusterId string `json:"clusterId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=clusterId
+	//  Clusters to forward logs from.  A forwarder is run for each cluster in the list.  Either this
+	//  field or .spec.ocm.clusterId must be set.
+	//
+	Clusters []OCMLogForwarderSpecOcmCluster `json:"clusters,omitempty"`
+
+	// +kubebuilder:default="5m"
+	// +kubebuilder:validation:Optional
+	// (Default: "5m")
+	//  +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m') && duration(self) <= duration('24h')",message="pollInterval must be in the range of 1m to 24h"
+	//  How frequently the controller will poll the OpenShift Cluster Manager console for service logs, such
+	//  as '5m' or '1h'.  Must be in the range of 1 minute to 24 hours.
+	//
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+
+	// +kubebuilder:default="https://api.openshift.com"
+	// +kubebuilder:validation:Optional
+	// (Default: "https://api.openshift.com")
+	//  URL of the OpenShift Cluster Manager API from which service logs are retrieved.  Set this to use
+	//  a non-default OCM environment, such as 'https://api.stage.openshift.com' for staging or the API
+	//  of a FedRAMP environment.
+	//
+	ApiURL string `json:"apiURL,omitempty"`
+
+	// +kubebuilder:default="https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+	// +kubebuilder:validation:Optional
+	// (Default: "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token")
+	//  URL of the SSO token endpoint which is used to exchange the OCM credentials for an access token.
+	//  Set this when the OCM environment from .spec.ocm.apiURL uses a different SSO.
+	//
+	TokenURL string `json:"tokenURL,omitempty"`
+}
+
+type OCMLogForwarderSpecOcmCluster struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	//  Cluster ID of the cluster to forward logs from.  The Cluster ID is used as the value of a label, and
+	//  in the name of the deployment which forwards logs for the cluster, which is the name of this
+	//  resource followed by '-' and the Cluster ID.
+	//
+	ClusterId string `json:"clusterId"`
+
+	// +kubebuilder:validation:Optional
+	//  The secret containing the OCM credentials for this cluster.  If unset, the secret from
+	//  .spec.ocm.secretRef is used.  When using the 'offlineToken' authentication type, a secret which
+	//  is shared by multiple clusters should have a key/value pair of clusterId=ocmTokenJson for each
+	//  of those clusters.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Index which overrides the backend index for the service logs of this cluster.  Applies to the
+	//  'ElasticSearch' and 'Splunk' backends.  For the 'ElasticSearch' backend, this also overrides the
+	//  indexPattern and ilm.rolloverAlias of the backend.
+	//
+	Index string `json:"index,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Labels which are merged over the backend stream labels for the service logs of this cluster.
+	//  Applies to the 'Loki' backend.
+	//
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type OCMLogForwarderSpecFilter struct {
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:Enum=Debug;Info;Warning;Error;Fatal
+	//  Minimum severity of the service logs which are forwarded.  For example, 'Warning' forwards service
+	//  logs with a severity of 'Warning', 'Error' or 'Fatal'.
+	//
+	MinSeverity string `json:"minSeverity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Service names of the service logs which are forwarded.  When set, service logs from any other
+	//  service are not forwarded.
+	//
+	IncludeServiceNames []string `json:"includeServiceNames,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Service names of the service logs which are not forwarded.
+	//
+	ExcludeServiceNames []string `json:"excludeServiceNames,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Regular expression, in Go RE2 syntax, which the summary of a service log must match to be forwarded.
+	//
+	SummaryRegex string `json:"summaryRegex,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:Minimum=1
+	//  Maximum age, in minutes, of the service logs which are forwarded.  Older service logs, such as those
+	//  found when the forwarder first starts, are not forwarded.
+	//
+	MaxAgeMinutes int `json:"maxAgeMinutes,omitempty"`
+}
+
+type OCMLogForwarderSpecProxy struct {
+	// +kubebuilder:validation:Optional
+	//  URL of the proxy for http requests, which is passed to the forwarder as HTTP_PROXY.
+	//
+	HttpProxy string `json:"httpProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  URL of the proxy for https requests, which is passed to the forwarder as HTTPS_PROXY.
+	//
+	HttpsProxy string `json:"httpsProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Comma-separated list of hosts, domains and CIDRs which bypass the proxy, which is passed to the
+	//  forwarder as NO_PROXY.
+	//
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Inherit the proxy configuration from the cluster-wide config.openshift.io/v1 Proxy object named
+	//  'cluster' when none of httpProxy, httpsProxy and noProxy are set.  Only applies to OpenShift.
+	//
+	InheritClusterProxy bool `json:"inheritClusterProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Inject the trusted CA bundle of the cluster, which includes the certificate authority of the proxy,
+	//  into the forwarder using a ConfigMap with the 'config.openshift.io/inject-trusted-cabundle' label.
+	//  Only applies to OpenShift.
+	//
+	InjectTrustedCABundle bool `json:"injectTrustedCABundle,omitempty"`
+}
+
+// OCMLogForwarderSpecBackend is a discriminated union of the backends where logs are sent and stored.  The
+// type field selects the backend, and only the member which matches the type may be set.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.elasticSearch) == (self.type == 'ElasticSearch')",message="elasticSearch must be set if, and only if, type is 'ElasticSearch'"
+// +kubebuilder:validation:XValidation:rule="has(self.splunk) == (self.type == 'Splunk')",message="splunk must be set if, and only if, type is 'Splunk'"
+// +kubebuilder:validation:XValidation:rule="has(self.loki) == (self.type == 'Loki')",message="loki must be set if, and only if, type is 'Loki'"
+// +kubebuilder:validation:XValidation:rule="has(self.kafka) == (self.type == 'Kafka')",message="kafka must be set if, and only if, type is 'Kafka'"
+// +kubebuilder:validation:XValidation:rule="has(self.s3) == (self.type == 'S3')",message="s3 must be set if, and only if, type is 'S3'"
+type OCMLogForwarderSpecBackend struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	//  Unique name of the backend.  Must be 32 characters or less and consist of lower case alphanumeric
+	//  characters or '-'.
+	//
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	//  +kubebuilder:validation:Enum=ElasticSearch;Splunk;Loki;Kafka;S3
+	//  Backend type where logs are sent and stored.  Requires the member of the same name to be set, such
+	//  as elasticSearch for 'ElasticSearch' or s3 for 'S3'.
+	//
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	ElasticSearch *OCMLogForwarderSpecBackendElasticSearch `json:"elasticSearch,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Splunk *OCMLogForwarderSpecBackendSplunk `json:"splunk,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Loki *OCMLogForwarderSpecBackendLoki `json:"loki,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Kafka *OCMLogForwarderSpecBackendKafka `json:"kafka,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	S3 *OCMLogForwarderSpecBackendS3 `json:"s3,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearch struct {
+	// +kubebuilder:default="elastic-auth"
+	// +kubebuilder:validation:Optional
+	// (Default: "elastic-auth")
+	//  The secret should contain the authentication information for the ElasticSearch connection.  See
+	//  the authType field for more information on secret requirements.  This secret should exist in the
+	//  same namespace as the OCMLogForwarder resource.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:default="https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200"
+	// +kubebuilder:validation:Optional
+	// (Default: "https://elasticsearch-es-http.elastic-system.svc.cluster.local:9200")
+	//  URL of ElasticSearch to which to ship logs.
+	//
+	Url string `json:"url,omitempty"`
+
+	// +kubebuilder:default="basic"
+	// +kubebuilder:validation:Optional
+	// (Default: "basic")
+	//  +kubebuilder:validation:Enum=basic;apikey
+	//  ElasticSearch authentication type to use.
+	//
+	//  * 'basic': For 'basic' authentication, the secret from secretRef should contain the basic
+	//  authentication information for the ElasticSearch connection containing only a single key/value pair
+	//  with the key as the username and the value as the password.
+	//
+	//  * 'apikey': For 'apikey' authentication, the secret from secretRef should contain an ElasticSearch
+	//  API key, either as the base64 encoded API key at key 'encoded', or as the API key id and API key at
+	//  keys 'id' and 'api_key'.  The key names match the fields returned by the ElasticSearch create API
+	//  key API.
+	//
+	AuthType string `json:"authType,omitempty"`
+
+	// +kubebuilder:default="ocm_service_logs"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm_service_logs")
+	//  +kubebuilder:validation:MaxLength=128
+	//  Index name in ElasticSearch where service logs are sent.  Index name must be 128 characters or less.
+	//
+	Index string `json:"index,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:MaxLength=128
+	//  Index name pattern in ElasticSearch where service logs are sent, which takes precedence over the
+	//  index field.  The pattern may contain date math within braces, which is resolved by the forwarder
+	//  in UTC when each service log is sent, such as 'ocm_service_logs-{yyyy.MM.dd}'.  Supported date math
+	//  tokens are 'yyyy', 'yy', 'MM', 'dd' and 'HH', which may be separated by '.', '-' or '_'.
+	//
+	IndexPattern string `json:"indexPattern,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Index lifecycle management configuration, so that the retention of service logs may be managed by
+	//  ElasticSearch lifecycle policies.
+	//
+	ILM OCMLogForwarderSpecBackendElasticSearchILM `json:"ilm,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Data stream configuration.  When enabled, service logs are written to an ElasticSearch data stream
+	//  instead of a plain index.
+	//
+	DataStream OCMLogForwarderSpecBackendElasticSearchDataStream `json:"dataStream,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Whether the operator creates and updates an index template, with explicit mappings for the fields
+	//  of OCM service logs, for the indices to which service logs are sent.  The index template is
+	//  provisioned using the ElasticSearch REST API prior to creating the forwarder.
+	//
+	ManageIndexTemplate bool `json:"manageIndexTemplate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:Pattern=`^[1-9][0-9]*(d|h|m|s)$`
+	//  Duration for which service logs are retained, such as '30d'.  When set, the operator creates and
+	//  updates an index lifecycle policy, named by ilm.policyName or generated from the name of this
+	//  resource, which deletes indices once they reach this age.  Requires manageIndexTemplate or
+	//  dataStream.enabled.
+	//
+	Retention string `json:"retention,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	TLS OCMLogForwarderSpecBackendElasticSearchTLS `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Reference to an ECK managed Elasticsearch.  When set, the url, credentials and certificate
+	//  authority are resolved from the referenced Elasticsearch, and the url, secretRef, authType and
+	//  tls.caRef fields are ignored.
+	//
+	ElasticsearchRef OCMLogForwarderSpecBackendElasticSearchRef `json:"elasticsearchRef,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearchDataStream struct {
+	// +kubebuilder:validation:Optional
+	//  Whether service logs are written to the data stream.  When enabled, the index template which enables
+	//  the data stream is always managed by the operator, and the index field is ignored.  Cannot be used
+	//  with indexPattern or ilm.rolloverAlias.
+	//
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:default="default"
+	// +kubebuilder:validation:Optional
+	// (Default: "default")
+	//  Namespace of the data stream, which follows the ElasticSearch data stream naming scheme.  Service
+	//  logs are written to the data stream named 'logs-ocm.service-<namespace>'.  The namespace must be
+	//  lowercase and must not contain '-'.
+	//
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearchILM struct {
+	// +kubebuilder:validation:Optional
+	//  Name of the ElasticSearch index lifecycle policy which is applied to the indices that the forwarder
+	//  creates.  Required when rolloverAlias is set.
+	//
+	PolicyName string `json:"policyName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  +kubebuilder:validation:MaxLength=128
+	//  Rollover alias to which service logs are sent.  When set, the forwarder writes to the alias, which
+	//  is rolled over to a new index by the index lifecycle policy, and the index field of the backend is
+	//  ignored.  Cannot be used with indexPattern.
+	//
+	RolloverAlias string `json:"rolloverAlias,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearchRef struct {
+	// +kubebuilder:validation:Optional
+	//  Name of the elasticsearch.k8s.elastic.co Elasticsearch object.
+	//
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Namespace of the elasticsearch.k8s.elastic.co Elasticsearch object.  If unset, the namespace of the
+	//  OCMLogForwarder resource is used.  An Elasticsearch in another namespace must list the namespace of
+	//  the OCMLogForwarder resource in its apps.dustinscott.io/allowed-namespaces annotation.
+	//
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearchTLS struct {
+	// +kubebuilder:validation:Optional
+	//  Certificate authority bundle used to verify the certificate presented by ElasticSearch, such as the
+	//  CA of an ECK managed ElasticSearch.  If unset, the system trust store is used.
+	//
+	CARef OCMLogForwarderSpecBackendElasticSearchTLSCARef `json:"caRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  The secret should contain the client certificate and key, at keys 'tls.crt' and 'tls.key', which are
+	//  presented to ElasticSearch for mutual TLS.  This secret should exist in the same namespace as the
+	//  OCMLogForwarder resource.
+	//
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Skip verification of the certificate presented by ElasticSearch.  This should only be used for testing.
+	//
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendElasticSearchTLSCARef struct {
+	// +kubebuilder:default="Secret"
+	// +kubebuilder:validation:Optional
+	// (Default: "Secret")
+	//  +kubebuilder:validation:Enum=Secret;ConfigMap
+	//  Kind of the object which contains the certificate authority bundle.
+	//
+	Kind string `json:"kind,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Name of the object, in the same namespace as the OCMLogForwarder resource, which contains the
+	//  certificate authority bundle.
+	//
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:default="ca.crt"
+	// +kubebuilder:validation:Optional
+	// (Default: "ca.crt")
+	//  Key of the object which contains the PEM encoded certificate authority bundle.
+	//
+	Key string `json:"key,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendSplunk struct {
+	// +kubebuilder:default="splunk-hec-token"
+	// +kubebuilder:validation:Optional
+	// (Default: "splunk-hec-token")
+	//  The secret should contain the Splunk HTTP Event Collector token used to authenticate to the Splunk
+	//  HTTP Event Collector.  It should have a single key/value pair with the form of token=hecToken.  This
+	//  secret should exist in the same namespace as the OCMLogForwarder resource.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  URL of the Splunk HTTP Event Collector to which to ship logs.  It shows up in a form such as
+	//  'https://splunk.example.com:8088'.
+	//
+	Url string `json:"url,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Index in Splunk where service logs are sent.  If unset, the default index of the HTTP Event Collector
+	//  token is used.
+	//
+	Index string `json:"index,omitempty"`
+
+	// +kubebuilder:default="ocm:servicelog"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm:servicelog")
+	//  Source type which is set on the events that are sent to Splunk.
+	//
+	SourceType string `json:"sourceType,omitempty"`
+
+	// +kubebuilder:default="ocm-log-forwarder"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm-log-forwarder")
+	//  Source which is set on the events that are sent to Splunk.
+	//
+	Source string `json:"source,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Skip verification of the TLS certificate presented by the Splunk HTTP Event Collector.  This should
+	//  only be used for testing.
+	//
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendLoki struct {
+	// +kubebuilder:default="loki-auth"
+	// +kubebuilder:validation:Optional
+	// (Default: "loki-auth")
+	//  The secret should contain the authentication information for the Loki connection.  See the
+	//  authType field for more information on secret requirements.  This secret should exist in the same
+	//  namespace as the OCMLogForwarder resource.  Unused when authType is 'none'.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Push URL of Loki to which to ship logs.  It shows up in a form such as
+	//  'http://loki-gateway.loki.svc.cluster.local/loki/api/v1/push'.
+	//
+	Url string `json:"url,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Tenant ID which is sent as the X-Scope-OrgID header when Loki is running in multi-tenant mode.
+	//
+	TenantId string `json:"tenantId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Static labels which are added to the stream of each service log that is sent to Loki.  Label names
+	//  must match the regex '[a-zA-Z_][a-zA-Z0-9_]*'.
+	//
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +kubebuilder:default="none"
+	// +kubebuilder:validation:Optional
+	// (Default: "none")
+	//  +kubebuilder:validation:Enum=none;basic;bearer
+	//  Loki authentication type to use.
+	//
+	//  * 'none': No authentication is sent to Loki.
+	//
+	//  * 'basic': For 'basic' authentication, the secret from secretRef should contain the basic
+	//  authentication information for the Loki connection containing only a single key/value pair with
+	//  the key as the username and the value as the password.
+	//
+	//  * 'bearer': For 'bearer' authentication, the secret from secretRef should contain the bearer token
+	//  for the Loki connection with a single key/value pair with the form of token=bearerToken.
+	//
+	AuthType string `json:"authType,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendKafka struct {
+	// +kubebuilder:default="kafka-auth"
+	// +kubebuilder:validation:Optional
+	// (Default: "kafka-auth")
+	//  The secret should contain the credentials for the Kafka connection.  See the authType field for
+	//  more information on secret requirements.  This secret should exist in the same namespace as the
+	//  OCMLogForwarder resource.  Unused when authType is 'none'.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Kafka bootstrap servers, in host:port form, to which to produce service logs.
+	//
+	BootstrapServers []string `json:"bootstrapServers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Topic to which each service log is produced as a JSON record.
+	//
+	Topic string `json:"topic,omitempty"`
+
+	// +kubebuilder:default="clusterId"
+	// +kubebuilder:validation:Optional
+	// (Default: "clusterId")
+	//  +kubebuilder:validation:Enum=clusterId;logId
+	//  Strategy used to set the key of each produced record.  Use 'clusterId' to keep the service logs of
+	//  a cluster in a single partition or 'logId' to spread service logs across partitions.
+	//
+	KeyStrategy string `json:"keyStrategy,omitempty"`
+
+	// +kubebuilder:default="none"
+	// +kubebuilder:validation:Optional
+	// (Default: "none")
+	//  +kubebuilder:validation:Enum=none;scram-sha-256;scram-sha-512;mtls
+	//  Kafka authentication type to use.
+	//
+	//  * 'none': No authentication is used for the Kafka connection.
+	//
+	//  * 'scram-sha-256' and 'scram-sha-512': For SASL/SCRAM authentication, the secret from secretRef
+	//  should contain only a single key/value pair with the key as the username and the value as the
+	//  password.
+	//
+	//  * 'mtls': For mutual TLS authentication, the secret from secretRef should contain the client
+	//  certificate and key at keys 'tls.crt' and 'tls.key', and optionally the certificate authority used
+	//  to verify the brokers at key 'ca.crt'.
+	//
+	AuthType string `json:"authType,omitempty"`
+}
+
+type OCMLogForwarderSpecBackendS3 struct {
+	// +kubebuilder:default="s3-credentials"
+	// +kubebuilder:validation:Optional
+	// (Default: "s3-credentials")
+	//  The secret should contain the credentials for the object storage connection with the access key id
+	//  at key 'AWS_ACCESS_KEY_ID' and the secret access key at key 'AWS_SECRET_ACCESS_KEY'.  This secret
+	//  should exist in the same namespace as the OCMLogForwarder resource.
+	//
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Endpoint of the S3-compatible object storage, such as 'https://minio.minio.svc.cluster.local:9000'.
+	//  Leave unset to use the AWS S3 endpoint for the region.
+	//
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	//  Bucket in which service log archives are stored.
+	//
+	Bucket string `json:"bucket,omitempty"`
+
+	// +kubebuilder:default="ocm-service-logs/{clusterId}/{year}/{month}/{day}"
+	// +kubebuilder:validation:Optional
+	// (Default: "ocm-service-logs/{clusterId}/{year}/{month}/{day}")
+	//  Template of the key prefix for each archive object.  The placeholders '{clusterId}', '{year}',
+	//  '{month}' and '{day}' are replaced with the cluster ID and the UTC date at which the object is written.
+	//
+	Prefix string `json:"prefix,omitempty"`
+
+	// +kubebuilder:default="us-east-1"
+	// +kubebuilder:validation:Optional
+	// (Default: "us-east-1")
+	//  Region of the bucket.
+	//
+	Region string `json:"region,omitempty"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	// (Default: false)
+	//  Use path-style addressing (endpoint/bucket/key) rather than virtual-hosted-style addressing
+	//  (bucket.endpoint/key).  This is commonly required by MinIO and other S3-compatible object storage.
+	//
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
+	// +kubebuilder:default="jsonl-gzip"
+	// +kubebuilder:validation:Optional
+	// (Default: "jsonl-gzip")
+	//  +kubebuilder:validation:Enum=jsonl;jsonl-gzip
+	//  Format of each archive object.  Use 'jsonl' for newline-delimited JSON or 'jsonl-gzip' for gzip
+	//  compressed newline-delimited JSON.
+	//
+	Format string `json:"format,omitempty"`
+
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Optional
+	// (Default: 60)
+	//  +kubebuilder:validation:Minimum=1
+	//  +kubebuilder:validation:Maximum=1440
+	//  How frequently, in minutes, buffered service logs are flushed to a new archive object.  Must be in the
+	//  range of 1 minute to 1440 minutes (1 day).
+	//
+	FlushIntervalMinutes int `json:"flushIntervalMinutes,omitempty"`
+}
+
+// OCMLogForwarderStatus defines the observed state of OCMLogForwarder.
+type OCMLogForwarderStatus struct {
+	Created               bool                        `json:"created,omitempty"`
+	DependenciesSatisfied bool                        `json:"dependenciesSatisfied,omitempty"`
+	Conditions            []*OCMLogForwarderCondition `json:"conditions,omitempty"`
+	Resources             []*status.ChildResource     `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the secrets referenced by this resource are valid.  See the SecretsValid
+	// condition for the reason that the secrets are invalid.
+	SecretsValid bool `json:"secretsValid,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the index templates and index lifecycle policies managed by this resource have been
+	// provisioned in ElasticSearch.  See the Provision-ElasticSearch phase condition for the reason that
+	// provisioning failed.
+	ElasticSearchProvisioned bool `json:"elasticSearchProvisioned,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// The forwarding status of each cluster which logs are forwarded from.
+	Clusters []OCMLogForwarderStatusCluster `json:"clusters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image which the forwarder runs, which is the requested image pinned to the digest that the requested
+	// version resolved to, such as 'ghcr.io/scottd018/ocm-log-forwarder:latest@sha256:...'.  See the
+	// ImageResolved condition for the reason that the version could not be resolved.
+	ResolvedImage string `json:"resolvedImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Reason that the requested version could not be resolved when it was last resolved, in which case the
+	// forwarder runs the previously resolved image, or the requested image when it was never resolved.
+	ImageResolutionFailure string `json:"imageResolutionFailure,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Time that the requested version could not be resolved.  The requested version is not resolved again
+	// until the image resolution retry interval has passed since this time.
+	ImageResolutionFailureTime *metav1.Time `json:"imageResolutionFailureTime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Value of the apps.dustinscott.io/resolve-image annotation when the requested version was last
+	// resolved.  The requested version is resolved again when the annotation is set to another value.
+	ResolveImageRequest string `json:"resolveImageRequest,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether forwarding is suspended, in which case the forwarder deployments are scaled to zero.  See
+	// the Suspended condition for when forwarding was suspended.
+	Suspended bool `json:"suspended,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Replica count of each forwarder deployment, by deployment name, when forwarding was suspended.
+	// These replica counts are restored, and cleared, when forwarding is resumed.
+	SuspendedReplicas map[string]int32 `json:"suspendedReplicas,omitempty"`
+}
+
+// OCMLogForwarderCondition defines a phase condition of the controller, along with the type and status of a
+// standard condition, so that the condition may be waited on with 'kubectl wait --for=condition=<type>'.
+type OCMLogForwarderCondition struct {
+	status.PhaseCondition `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// Type of the condition, which is the name of the phase unless the phase sets a condition with
+	// another name, such as SecretsValid for the Validate-Secrets phase.
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Status of the condition, which is True when the phase has completed, False when the phase has
+	// failed, and Unknown otherwise.
+	Status metav1.ConditionStatus `json:"status,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Reason for the status of the condition, which is the state of the phase.
+	Reason string `json:"reason,omitempty"`
+}
+
+// OCMLogForwarderStatusCluster defines the observed forwarding state of a single cluster.
+type OCMLogForwarderStatusCluster struct {
+	// Cluster ID of the cluster which logs are forwarded from.
+	ClusterId string `json:"clusterId"`
+
+	// Name of the deployment which forwards logs for the cluster.
+	Deployment string `json:"deployment"`
+
+	// Whether the cluster is being polled successfully, which is true once the forwarder deployment
+	// for the cluster has completed its rollout.
+	Polling bool `json:"polling"`
+
+	// Reason that the cluster is not being polled successfully.
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// OCMLogForwarder is the Schema for the ocmlogforwarders API.
+type OCMLogForwarder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              OCMLogForwarderSpec   `json:"spec,omitempty"`
+	Status            OCMLogForwarderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OCMLogForwarderList contains a list of OCMLogForwarder.
+type OCMLogForwarderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OCMLogForwarder `json:"items"`
+}
+
+// GetComponentGVK returns a GVK object for the component.
+func (*OCMLogForwarder) GetWorkloadGVK() schema.GroupVersionKind {
+	return GroupVersion.WithKind("OCMLogForwarder")
+}
+
+func init() {
+	SchemeBuilder.Register(&OCMLogForwarder{}, &OCMLogForwarderList{})
+}
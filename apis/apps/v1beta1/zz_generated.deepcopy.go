@@ -0,0 +1,487 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarder) DeepCopyInto(out *OCMLogForwarder) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarder.
+func (in *OCMLogForwarder) DeepCopy() *OCMLogForwarder {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OCMLogForwarder) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderCondition) DeepCopyInto(out *OCMLogForwarderCondition) {
+	*out = *in
+	out.PhaseCondition = in.PhaseCondition
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderCondition.
+func (in *OCMLogForwarderCondition) DeepCopy() *OCMLogForwarderCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderList) DeepCopyInto(out *OCMLogForwarderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OCMLogForwarder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderList.
+func (in *OCMLogForwarderList) DeepCopy() *OCMLogForwarderList {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OCMLogForwarderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpec) DeepCopyInto(out *OCMLogForwarderSpec) {
+	*out = *in
+	in.Ocm.DeepCopyInto(&out.Ocm)
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]OCMLogForwarderSpecBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Proxy = in.Proxy
+	in.Filter.DeepCopyInto(&out.Filter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpec.
+func (in *OCMLogForwarderSpec) DeepCopy() *OCMLogForwarderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackend) DeepCopyInto(out *OCMLogForwarderSpecBackend) {
+	*out = *in
+	if in.ElasticSearch != nil {
+		in, out := &in.ElasticSearch, &out.ElasticSearch
+		*out = new(OCMLogForwarderSpecBackendElasticSearch)
+		**out = **in
+	}
+	if in.Splunk != nil {
+		in, out := &in.Splunk, &out.Splunk
+		*out = new(OCMLogForwarderSpecBackendSplunk)
+		**out = **in
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(OCMLogForwarderSpecBackendLoki)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(OCMLogForwarderSpecBackendKafka)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(OCMLogForwarderSpecBackendS3)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackend.
+func (in *OCMLogForwarderSpecBackend) DeepCopy() *OCMLogForwarderSpecBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearch) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearch) {
+	*out = *in
+	out.ILM = in.ILM
+	out.DataStream = in.DataStream
+	out.TLS = in.TLS
+	out.ElasticsearchRef = in.ElasticsearchRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearch.
+func (in *OCMLogForwarderSpecBackendElasticSearch) DeepCopy() *OCMLogForwarderSpecBackendElasticSearch {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchDataStream) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchDataStream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchDataStream.
+func (in *OCMLogForwarderSpecBackendElasticSearchDataStream) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchDataStream {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchDataStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchILM) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchILM) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchILM.
+func (in *OCMLogForwarderSpecBackendElasticSearchILM) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchILM {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchILM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchRef) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchRef.
+func (in *OCMLogForwarderSpecBackendElasticSearchRef) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchRef {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLS) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchTLS) {
+	*out = *in
+	out.CARef = in.CARef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchTLS.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLS) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLSCARef) DeepCopyInto(out *OCMLogForwarderSpecBackendElasticSearchTLSCARef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendElasticSearchTLSCARef.
+func (in *OCMLogForwarderSpecBackendElasticSearchTLSCARef) DeepCopy() *OCMLogForwarderSpecBackendElasticSearchTLSCARef {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendElasticSearchTLSCARef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendKafka) DeepCopyInto(out *OCMLogForwarderSpecBackendKafka) {
+	*out = *in
+	if in.BootstrapServers != nil {
+		in, out := &in.BootstrapServers, &out.BootstrapServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendKafka.
+func (in *OCMLogForwarderSpecBackendKafka) DeepCopy() *OCMLogForwarderSpecBackendKafka {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendKafka)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendLoki) DeepCopyInto(out *OCMLogForwarderSpecBackendLoki) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendLoki.
+func (in *OCMLogForwarderSpecBackendLoki) DeepCopy() *OCMLogForwarderSpecBackendLoki {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendLoki)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendS3) DeepCopyInto(out *OCMLogForwarderSpecBackendS3) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendS3.
+func (in *OCMLogForwarderSpecBackendS3) DeepCopy() *OCMLogForwarderSpecBackendS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecBackendSplunk) DeepCopyInto(out *OCMLogForwarderSpecBackendSplunk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecBackendSplunk.
+func (in *OCMLogForwarderSpecBackendSplunk) DeepCopy() *OCMLogForwarderSpecBackendSplunk {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecBackendSplunk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecFilter) DeepCopyInto(out *OCMLogForwarderSpecFilter) {
+	*out = *in
+	if in.IncludeServiceNames != nil {
+		in, out := &in.IncludeServiceNames, &out.IncludeServiceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeServiceNames != nil {
+		in, out := &in.ExcludeServiceNames, &out.ExcludeServiceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecFilter.
+func (in *OCMLogForwarderSpecFilter) DeepCopy() *OCMLogForwarderSpecFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecOcm) DeepCopyInto(out *OCMLogForwarderSpecOcm) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]OCMLogForwarderSpecOcmCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.PollInterval = in.PollInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecOcm.
+func (in *OCMLogForwarderSpecOcm) DeepCopy() *OCMLogForwarderSpecOcm {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecOcm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecOcmCluster) DeepCopyInto(out *OCMLogForwarderSpecOcmCluster) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecOcmCluster.
+func (in *OCMLogForwarderSpecOcmCluster) DeepCopy() *OCMLogForwarderSpecOcmCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecOcmCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderSpecProxy) DeepCopyInto(out *OCMLogForwarderSpecProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderSpecProxy.
+func (in *OCMLogForwarderSpecProxy) DeepCopy() *OCMLogForwarderSpecProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderSpecProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderStatus) DeepCopyInto(out *OCMLogForwarderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*OCMLogForwarderCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(OCMLogForwarderCondition)
+				**out = **in
+			}
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]*status.ChildResource, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(status.ChildResource)
+				**out = **in
+			}
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]OCMLogForwarderStatusCluster, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageResolutionFailureTime != nil {
+		in, out := &in.ImageResolutionFailureTime, &out.ImageResolutionFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SuspendedReplicas != nil {
+		in, out := &in.SuspendedReplicas, &out.SuspendedReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderStatus.
+func (in *OCMLogForwarderStatus) DeepCopy() *OCMLogForwarderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMLogForwarderStatusCluster) DeepCopyInto(out *OCMLogForwarderStatusCluster) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMLogForwarderStatusCluster.
+func (in *OCMLogForwarderStatusCluster) DeepCopy() *OCMLogForwarderStatusCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMLogForwarderStatusCluster)
+	in.DeepCopyInto(out)
+	return out
+}
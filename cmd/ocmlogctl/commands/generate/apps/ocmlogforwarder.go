@@ -32,6 +32,7 @@ import (
 	cmdgenerate "github.com/scottd018/ocm-log-forwarder-operator/cmd/ocmlogctl/commands/generate"
 	// specific imports for workloads
 	v1alpha1ocmlogforwarder "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+	v1beta1ocmlogforwarder "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1/ocmlogforwarder"
 	//+kubebuilder:scaffold:operator-builder:imports
 )
 
@@ -75,6 +76,7 @@ func GenerateOCMLogForwarder(g *cmdgenerate.GenerateSubCommand) error {
 	type generateFunc func([]byte) ([]client.Object, error)
 	generateFuncMap := map[string]generateFunc{
 		"v1alpha1": v1alpha1ocmlogforwarder.GenerateForCLI,
+		"v1beta1":  v1beta1ocmlogforwarder.GenerateForCLI,
 		//+kubebuilder:scaffold:operator-builder:versionmap
 	}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps"
 
 	v1alpha1ocmlogforwarder "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+	v1beta1ocmlogforwarder "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1/ocmlogforwarder"
 	cmdinit "github.com/scottd018/ocm-log-forwarder-operator/cmd/ocmlogctl/commands/init"
 	//+kubebuilder:scaffold:operator-builder:imports
 )
@@ -40,6 +41,7 @@ func getOCMLogForwarderManifest(i *cmdinit.InitSubCommand) (string, error) {
 	// generate a map of all versions to samples for each api version created
 	manifestMap := map[string]string{
 		"v1alpha1": v1alpha1ocmlogforwarder.Sample(i.RequiredOnly),
+		"v1beta1":  v1beta1ocmlogforwarder.Sample(i.RequiredOnly),
 		//+kubebuilder:scaffold:operator-builder:versionmap
 	}
 
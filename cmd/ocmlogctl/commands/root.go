@@ -63,6 +63,7 @@ func (c *OcmlogctlCommand) newInitSubCommand() {
 
 	// add the init subcommands
 	initapps.NewOCMLogForwarderSubCommand(parentCommand)
+	initapps.NewClusterOCMLogForwarderSubCommand(subCommand(parentCommand, "init"))
 	//+kubebuilder:scaffold:operator-builder:subcommands:init
 }
 
@@ -72,6 +73,7 @@ func (c *OcmlogctlCommand) newGenerateSubCommand() {
 
 	// add the generate subcommands
 	generateapps.NewOCMLogForwarderSubCommand(parentCommand)
+	generateapps.NewClusterOCMLogForwarderSubCommand(subCommand(parentCommand, "generate"))
 	//+kubebuilder:scaffold:operator-builder:subcommands:generate
 }
 
@@ -81,6 +83,7 @@ func (c *OcmlogctlCommand) newVersionSubCommand() {
 
 	// add the version subcommands
 	versionapps.NewOCMLogForwarderSubCommand(parentCommand)
+	versionapps.NewClusterOCMLogForwarderSubCommand(subCommand(parentCommand, "version"))
 	//+kubebuilder:scaffold:operator-builder:subcommands:version
 }
 
@@ -90,3 +93,15 @@ func (c *OcmlogctlCommand) addSubCommands() {
 	c.newGenerateSubCommand()
 	c.newVersionSubCommand()
 }
+
+// subCommand returns the subcommand of a command with a given name, so that the subcommands for the
+// ClusterOCMLogForwarder kind are added beneath those for the OCMLogForwarder kind.
+func subCommand(parentCommand *cobra.Command, name string) *cobra.Command {
+	for _, command := range parentCommand.Commands() {
+		if command.Name() == name {
+			return command
+		}
+	}
+
+	return parentCommand
+}
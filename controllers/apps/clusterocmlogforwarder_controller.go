@@ -0,0 +1,328 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/predicates"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/clusterocmlogforwarder"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/dependencies"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/mutate"
+)
+
+// ClusterOCMLogForwarderReconciler reconciles a ClusterOCMLogForwarder object.
+type ClusterOCMLogForwarderReconciler struct {
+	client.Client
+	Name         string
+	Log          logr.Logger
+	Controller   controller.Controller
+	Events       record.EventRecorder
+	FieldManager string
+	Watches      []client.Object
+	Phases       *phases.Registry
+}
+
+func NewClusterOCMLogForwarderReconciler(mgr ctrl.Manager) *ClusterOCMLogForwarderReconciler {
+	return &ClusterOCMLogForwarderReconciler{
+		Name:         "ClusterOCMLogForwarder",
+		Client:       mgr.GetClient(),
+		Events:       mgr.GetEventRecorderFor("ClusterOCMLogForwarder-Controller"),
+		FieldManager: "ClusterOCMLogForwarder-reconciler",
+		Log:          ctrl.Log.WithName("controllers").WithName("apps").WithName("ClusterOCMLogForwarder"),
+		Watches:      []client.Object{},
+		Phases:       &phases.Registry{},
+	}
+}
+
+// +kubebuilder:rbac:groups=apps.dustinscott.io,resources=clusterocmlogforwarders,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.dustinscott.io,resources=clusterocmlogforwarders/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.dustinscott.io,resources=clusterocmlogforwarders/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.2/pkg/reconcile
+func (r *ClusterOCMLogForwarderReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	req, err := r.NewRequest(ctx, request)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	if err := phases.RegisterDeleteHooks(r, req); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// execute the phases
+	result, err := r.Phases.HandleExecution(r, req)
+	if err != nil || !result.IsZero() || !req.Workload.GetDeletionTimestamp().IsZero() {
+		return result, err
+	}
+
+	component, err := clusterocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return requeueImageResolution(component.ToOCMLogForwarder()), nil
+}
+
+func (r *ClusterOCMLogForwarderReconciler) NewRequest(ctx context.Context, request ctrl.Request) (*workload.Request, error) {
+	component := &appsv1alpha1.ClusterOCMLogForwarder{}
+
+	log := r.Log.WithValues(
+		"kind", component.GetWorkloadGVK().Kind,
+		"name", request.Name,
+	)
+
+	// get the component from the cluster
+	if err := r.Get(ctx, request.NamespacedName, component); err != nil {
+		if !apierrs.IsNotFound(err) {
+			log.Error(err, "unable to fetch workload")
+
+			return nil, fmt.Errorf("unable to fetch workload, %w", err)
+		}
+
+		return nil, err
+	}
+
+	// create the workload request
+	workloadRequest := &workload.Request{
+		Context:  ctx,
+		Workload: component,
+		Log:      log.WithValues("targetNamespace", component.GetTargetNamespace()),
+	}
+
+	return workloadRequest, nil
+}
+
+// GetResources resources runs the methods to properly construct the resources in memory.
+func (r *ClusterOCMLogForwarderReconciler) GetResources(req *workload.Request) ([]client.Object, error) {
+	component, err := clusterocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterocmlogforwarder.Generate(*component, r, req)
+}
+
+// GetEventRecorder returns the event recorder for writing kubernetes events.
+func (r *ClusterOCMLogForwarderReconciler) GetEventRecorder() record.EventRecorder {
+	return r.Events
+}
+
+// GetFieldManager returns the name of the field manager for the controller.
+func (r *ClusterOCMLogForwarderReconciler) GetFieldManager() string {
+	return r.FieldManager
+}
+
+// GetLogger returns the logger from the reconciler.
+func (r *ClusterOCMLogForwarderReconciler) GetLogger() logr.Logger {
+	return r.Log
+}
+
+// GetName returns the name of the reconciler.
+func (r *ClusterOCMLogForwarderReconciler) GetName() string {
+	return r.Name
+}
+
+// GetController returns the controller object associated with the reconciler.
+func (r *ClusterOCMLogForwarderReconciler) GetController() controller.Controller {
+	return r.Controller
+}
+
+// GetWatches returns the objects which are current being watched by the reconciler.
+func (r *ClusterOCMLogForwarderReconciler) GetWatches() []client.Object {
+	return r.Watches
+}
+
+// SetWatch appends a watch to the list of currently watched objects.
+func (r *ClusterOCMLogForwarderReconciler) SetWatch(watch client.Object) {
+	r.Watches = append(r.Watches, watch)
+}
+
+// CheckReady will return whether a component is ready.
+func (r *ClusterOCMLogForwarderReconciler) CheckReady(req *workload.Request) (bool, error) {
+	return clusterocmlogforwarder.Phase(dependencies.OCMLogForwarderCheckReady)(r, req)
+}
+
+// Mutate will run the mutate function for the workload.
+// WARN: this will be deprecated in the future.  See apis/group/version/kind/mutate*
+func (r *ClusterOCMLogForwarderReconciler) Mutate(
+	req *workload.Request,
+	object client.Object,
+) ([]client.Object, bool, error) {
+	return mutate.OCMLogForwarderMutate(r, req, object)
+}
+
+func (r *ClusterOCMLogForwarderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.InitializePhases()
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.ClusterOCMLogForwarder{}, builder.WithPredicates(predicates.WorkloadPredicates()))
+
+	// the child resources are owned by the cluster-scoped workload across the namespace boundary, so that they
+	// are garbage collected when the workload is deleted and requests are enqueued for the workload, rather
+	// than for the namespace of the child resource, when they drift.
+	for _, owned := range ownedResources() {
+		controllerBuilder = controllerBuilder.Owns(owned, builder.WithPredicates(ownedPredicates()))
+
+		r.SetWatch(owned)
+	}
+
+	// watch the secrets which are referenced by the workload so that the forwarder is restarted
+	// when a secret is rotated.
+	controllerBuilder = controllerBuilder.Watches(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(r.secretRequests),
+		builder.WithPredicates(secretPredicates()),
+	)
+
+	// watch the ECK managed elasticsearch objects which are referenced by the workload so that the url,
+	// credentials and certificate authority are resolved again when they change.
+	controllerBuilder, err := watchIfInstalled(
+		mgr,
+		controllerBuilder,
+		appsv1alpha1.ElasticsearchGroupVersionKind,
+		func(object client.Object) []reconcile.Request {
+			return r.elasticsearchRequests(object.GetNamespace(), object.GetName())
+		},
+		elasticsearchPredicates(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// watch the cluster-wide proxy configuration of OpenShift so that workloads which inherit it are
+	// updated when it changes.
+	controllerBuilder, err = watchIfInstalled(
+		mgr,
+		controllerBuilder,
+		appsv1alpha1.ClusterProxyGroupVersionKind,
+		r.clusterProxyRequests,
+		clusterProxyPredicates(),
+	)
+	if err != nil {
+		return err
+	}
+
+	baseController, err := controllerBuilder.Build(r)
+	if err != nil {
+		return fmt.Errorf("unable to setup controller, %w", err)
+	}
+
+	r.Controller = baseController
+
+	return nil
+}
+
+// workloadRequests returns the reconciliation requests for the workloads whose forwarder matches a filter.
+func (r *ClusterOCMLogForwarderReconciler) workloadRequests(
+	matches func(*appsv1alpha1.OCMLogForwarder) bool,
+	keysAndValues ...interface{},
+) []reconcile.Request {
+	workloads := &appsv1alpha1.ClusterOCMLogForwarderList{}
+
+	if err := r.List(context.Background(), workloads); err != nil {
+		r.Log.Error(err, "unable to list workloads", keysAndValues...)
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range workloads.Items {
+		if matches(workloads.Items[i].ToOCMLogForwarder()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&workloads.Items[i]),
+			})
+		}
+	}
+
+	return requests
+}
+
+// secretRequests returns the reconciliation requests for the workloads which reference a secret in their
+// target namespace, including the secrets which are managed by ECK for an elasticsearch that is referenced by
+// a workload.
+func (r *ClusterOCMLogForwarderReconciler) secretRequests(secret client.Object) []reconcile.Request {
+	if name, ok := secret.GetLabels()[elasticsearchClusterNameLabel]; ok {
+		return r.elasticsearchRequests(secret.GetNamespace(), name)
+	}
+
+	return r.workloadRequests(
+		func(forwarder *appsv1alpha1.OCMLogForwarder) bool {
+			if forwarder.Namespace != secret.GetNamespace() {
+				return false
+			}
+
+			for _, secretRef := range forwarder.GetSecretRefs() {
+				if secretRef == secret.GetName() {
+					return true
+				}
+			}
+
+			return false
+		},
+		"secret", secret.GetName(),
+		"namespace", secret.GetNamespace(),
+	)
+}
+
+// elasticsearchRequests returns the reconciliation requests for the workloads which reference an ECK managed
+// elasticsearch.
+func (r *ClusterOCMLogForwarderReconciler) elasticsearchRequests(namespace, name string) []reconcile.Request {
+	return r.workloadRequests(
+		func(forwarder *appsv1alpha1.OCMLogForwarder) bool {
+			return forwarder.ReferencesElasticsearch(namespace, name)
+		},
+		"elasticsearch", name,
+		"namespace", namespace,
+	)
+}
+
+// clusterProxyRequests returns the reconciliation requests for the workloads which inherit the cluster-wide
+// proxy configuration.
+func (r *ClusterOCMLogForwarderReconciler) clusterProxyRequests(proxy client.Object) []reconcile.Request {
+	return r.workloadRequests(
+		func(forwarder *appsv1alpha1.OCMLogForwarder) bool {
+			return forwarder.Spec.Proxy.InheritClusterProxy
+		},
+		"proxy", proxy.GetName(),
+	)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apps
+
+import (
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/clusterocmlogforwarder"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/dependencies"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/elasticsearch"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/prune"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/registry"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/secrets"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/suspend"
+)
+
+// InitializePhases defines what phases should be run for each event loop. phases are executed
+// in the order they are listed.  The phases are those of an OCMLogForwarder, which are run for
+// the OCMLogForwarder that the workload deploys to its target namespace.
+func (r *ClusterOCMLogForwarderReconciler) InitializePhases() {
+	// the image resolver is shared by the create and update phases, so that its registry clients are reused
+	resolver := registry.NewResolver()
+
+	// Create Phases
+	r.Phases.Register(
+		"Dependency",
+		clusterocmlogforwarder.Phase(dependencies.OCMLogForwarderDependency),
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
+	r.Phases.Register(
+		"Validate-Secrets",
+		clusterocmlogforwarder.Phase(secrets.OCMLogForwarderValidate),
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Provision-ElasticSearch",
+		clusterocmlogforwarder.Phase(elasticsearch.OCMLogForwarderProvision),
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Resolve-Image",
+		clusterocmlogforwarder.Phase(resolver.OCMLogForwarderResolveImage),
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Suspend",
+		clusterocmlogforwarder.Phase(suspend.OCMLogForwarderSuspend),
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Create-Resources",
+		phases.CreateResourcesPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Prune-Resources",
+		clusterocmlogforwarder.Phase(prune.OCMLogForwarderPrune),
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Check-Ready",
+		phases.CheckReadyPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
+	r.Phases.Register(
+		"Complete",
+		phases.CompletePhase,
+		phases.CreateEvent,
+	)
+
+	// Update Phases
+	r.Phases.Register(
+		"Dependency",
+		clusterocmlogforwarder.Phase(dependencies.OCMLogForwarderDependency),
+		phases.UpdateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
+	r.Phases.Register(
+		"Validate-Secrets",
+		clusterocmlogforwarder.Phase(secrets.OCMLogForwarderValidate),
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Provision-ElasticSearch",
+		clusterocmlogforwarder.Phase(elasticsearch.OCMLogForwarderProvision),
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Resolve-Image",
+		clusterocmlogforwarder.Phase(resolver.OCMLogForwarderResolveImage),
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Suspend",
+		clusterocmlogforwarder.Phase(suspend.OCMLogForwarderSuspend),
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Create-Resources",
+		phases.CreateResourcesPhase,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Prune-Resources",
+		clusterocmlogforwarder.Phase(prune.OCMLogForwarderPrune),
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Check-Ready",
+		phases.CheckReadyPhase,
+		phases.UpdateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
+	r.Phases.Register(
+		"Complete",
+		phases.CompletePhase,
+		phases.UpdateEvent,
+	)
+
+	// Delete Phases
+	r.Phases.Register(
+		"DeletionComplete",
+		phases.DeletionCompletePhase,
+		phases.DeleteEvent,
+	)
+}
@@ -19,16 +19,31 @@ package apps
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
 	"github.com/nukleros/operator-builder-tools/pkg/controller/predicates"
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
 	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
@@ -36,6 +51,9 @@ import (
 	"github.com/scottd018/ocm-log-forwarder-operator/internal/mutate"
 )
 
+// elasticsearchClusterNameLabel is the label which ECK sets on the secrets that it manages for an elasticsearch.
+const elasticsearchClusterNameLabel = "elasticsearch.k8s.elastic.co/cluster-name"
+
 // OCMLogForwarderReconciler reconciles a OCMLogForwarder object.
 type OCMLogForwarderReconciler struct {
 	client.Client
@@ -92,7 +110,23 @@ func (r *OCMLogForwarderReconciler) Reconcile(ctx context.Context, request ctrl.
 	}
 
 	// execute the phases
-	return r.Phases.HandleExecution(r, req)
+	result, err := r.Phases.HandleExecution(r, req)
+	if err != nil || !result.IsZero() || !req.Workload.GetDeletionTimestamp().IsZero() {
+		return result, err
+	}
+
+	component, err := ocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return requeueImageResolution(component), nil
+}
+
+// requeueImageResolution returns the result of a reconciliation which has completed, which requeues the object
+// once the retry interval of an image, which could not be resolved, has passed so that it is resolved again.
+func requeueImageResolution(component *appsv1alpha1.OCMLogForwarder) ctrl.Result {
+	return ctrl.Result{RequeueAfter: component.GetImageResolutionRetryAfter(time.Now())}
 }
 
 func (r *OCMLogForwarderReconciler) NewRequest(ctx context.Context, request ctrl.Request) (*workload.Request, error) {
@@ -187,10 +221,55 @@ func (r *OCMLogForwarderReconciler) Mutate(
 func (r *OCMLogForwarderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.InitializePhases()
 
-	baseController, err := ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicates.WorkloadPredicates()).
-		For(&appsv1alpha1.OCMLogForwarder{}).
-		Build(r)
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.OCMLogForwarder{}, builder.WithPredicates(predicates.WorkloadPredicates()))
+
+	// own each of the child resource kinds up front so that drift is reconciled immediately, including
+	// after a restart of the controller.  the watches are stored on the reconciler so that they are not
+	// registered a second time when a child resource is created.
+	for _, owned := range ownedResources() {
+		controllerBuilder = controllerBuilder.Owns(owned, builder.WithPredicates(ownedPredicates()))
+
+		r.SetWatch(owned)
+	}
+
+	// watch the secrets which are referenced by the workload so that the forwarder is restarted
+	// when a secret is rotated.
+	controllerBuilder = controllerBuilder.Watches(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(r.secretRequests),
+		builder.WithPredicates(secretPredicates()),
+	)
+
+	// watch the ECK managed elasticsearch objects which are referenced by the workload so that the url,
+	// credentials and certificate authority are resolved again when they change.
+	controllerBuilder, err := watchIfInstalled(
+		mgr,
+		controllerBuilder,
+		appsv1alpha1.ElasticsearchGroupVersionKind,
+		func(object client.Object) []reconcile.Request {
+			return r.elasticsearchRequests(object.GetNamespace(), object.GetName())
+		},
+		elasticsearchPredicates(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// watch the cluster-wide proxy configuration of OpenShift so that workloads which inherit it are
+	// updated when it changes.
+	controllerBuilder, err = watchIfInstalled(
+		mgr,
+		controllerBuilder,
+		appsv1alpha1.ClusterProxyGroupVersionKind,
+		r.clusterProxyRequests,
+		clusterProxyPredicates(),
+	)
+	if err != nil {
+		return err
+	}
+
+	baseController, err := controllerBuilder.Build(r)
 	if err != nil {
 		return fmt.Errorf("unable to setup controller, %w", err)
 	}
@@ -199,3 +278,254 @@ func (r *OCMLogForwarderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	return nil
 }
+
+// watchIfInstalled watches the objects of a kind which is provided by an optional dependency, such as ECK or
+// OpenShift, only when the custom resource definition of the kind is installed.
+func watchIfInstalled(
+	mgr ctrl.Manager,
+	controllerBuilder *builder.Builder,
+	gvk schema.GroupVersionKind,
+	mapFunc handler.MapFunc,
+	predicates predicate.Predicate,
+) (*builder.Builder, error) {
+	if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return controllerBuilder, nil
+		}
+
+		return nil, fmt.Errorf("unable to determine if kind [%s] is installed, %w", gvk.String(), err)
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+
+	return controllerBuilder.Watches(
+		&source.Kind{Type: object},
+		handler.EnqueueRequestsFromMapFunc(mapFunc),
+		builder.WithPredicates(predicates),
+	), nil
+}
+
+// ownedResources returns the kinds of child resources which are generated by the ocmlogforwarder.CreateFuncs
+// and owned by the controller.  The type information is set so that the objects are comparable with the
+// watches which are set when a child resource is created.
+func ownedResources() []client.Object {
+	return []client.Object{
+		&corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		},
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		},
+		&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		},
+		&rbacv1.Role{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"},
+		},
+		&rbacv1.RoleBinding{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"},
+		},
+		&appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		},
+	}
+}
+
+// ownedPredicates returns the filters which are used to filter out the events of owned child resources
+// which do not require reconciliation, such as status-only updates.
+func ownedPredicates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// objects without a generation (e.g. rbac objects) do not have a status, so any update to
+			// them may have caused drift from the desired state
+			if e.ObjectNew.GetGeneration() == 0 {
+				return true
+			}
+
+			// objects with a generation only increment it when their spec changes, so an update
+			// without a generation change is a status or metadata update
+			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
+				return true
+			}
+
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				!reflect.DeepEqual(e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations())
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			// do not run reconciliation again when we just created the child resource
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// secretRequests returns the reconciliation requests for the workloads which reference a secret, including
+// the secrets which are managed by ECK for an elasticsearch that is referenced by a workload.
+func (r *OCMLogForwarderReconciler) secretRequests(secret client.Object) []reconcile.Request {
+	if name, ok := secret.GetLabels()[elasticsearchClusterNameLabel]; ok {
+		return r.elasticsearchRequests(secret.GetNamespace(), name)
+	}
+
+	workloads := &appsv1alpha1.OCMLogForwarderList{}
+
+	if err := r.List(context.Background(), workloads, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(
+			err, "unable to list workloads for secret",
+			"name", secret.GetName(),
+			"namespace", secret.GetNamespace(),
+		)
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range workloads.Items {
+		for _, secretRef := range workloads.Items[i].GetSecretRefs() {
+			if secretRef == secret.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&workloads.Items[i]),
+				})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// elasticsearchRequests returns the reconciliation requests for the workloads which reference an ECK managed
+// elasticsearch.  The elasticsearch may be in a different namespace than the workload, so workloads are listed
+// across all namespaces.
+func (r *OCMLogForwarderReconciler) elasticsearchRequests(namespace, name string) []reconcile.Request {
+	workloads := &appsv1alpha1.OCMLogForwarderList{}
+
+	if err := r.List(context.Background(), workloads); err != nil {
+		r.Log.Error(
+			err, "unable to list workloads for elasticsearch",
+			"name", name,
+			"namespace", namespace,
+		)
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range workloads.Items {
+		if workloads.Items[i].ReferencesElasticsearch(namespace, name) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&workloads.Items[i]),
+			})
+		}
+	}
+
+	return requests
+}
+
+// clusterProxyRequests returns the reconciliation requests for the workloads which inherit the cluster-wide
+// proxy configuration.
+func (r *OCMLogForwarderReconciler) clusterProxyRequests(proxy client.Object) []reconcile.Request {
+	workloads := &appsv1alpha1.OCMLogForwarderList{}
+
+	if err := r.List(context.Background(), workloads); err != nil {
+		r.Log.Error(err, "unable to list workloads for cluster proxy", "name", proxy.GetName())
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range workloads.Items {
+		if workloads.Items[i].Spec.Proxy.InheritClusterProxy {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&workloads.Items[i]),
+			})
+		}
+	}
+
+	return requests
+}
+
+// clusterProxyPredicates returns the filters which are used to filter out the events of the cluster-wide proxy
+// configuration which do not change its status, which holds the effective proxy values.
+func clusterProxyPredicates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObject, ok := e.ObjectOld.(*unstructured.Unstructured)
+			if !ok {
+				return true
+			}
+
+			newObject, ok := e.ObjectNew.(*unstructured.Unstructured)
+			if !ok {
+				return true
+			}
+
+			return !reflect.DeepEqual(oldObject.Object["status"], newObject.Object["status"])
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// elasticsearchPredicates returns the filters which are used to filter out the events of ECK managed
+// elasticsearch objects which do not change their spec or health.
+func elasticsearchPredicates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
+				return true
+			}
+
+			oldObject, ok := e.ObjectOld.(*unstructured.Unstructured)
+			if !ok {
+				return true
+			}
+
+			newObject, ok := e.ObjectNew.(*unstructured.Unstructured)
+			if !ok {
+				return true
+			}
+
+			oldHealth, _, _ := unstructured.NestedString(oldObject.Object, "status", "health")
+			newHealth, _, _ := unstructured.NestedString(newObject.Object, "status", "health")
+
+			return oldHealth != newHealth
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// secretPredicates returns the filters which are used to filter out the events of secrets which do not
+// change the contents of the secret.
+func secretPredicates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, ok := e.ObjectOld.(*corev1.Secret)
+			if !ok {
+				return true
+			}
+
+			newSecret, ok := e.ObjectNew.(*corev1.Secret)
+			if !ok {
+				return true
+			}
+
+			return !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -21,25 +21,65 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/dependencies"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/elasticsearch"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/prune"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/registry"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/secrets"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/suspend"
 )
 
 // InitializePhases defines what phases should be run for each event loop. phases are executed
 // in the order they are listed.
 func (r *OCMLogForwarderReconciler) InitializePhases() {
+	// the image resolver is shared by the create and update phases, so that its registry clients are reused
+	resolver := registry.NewResolver()
+
 	// Create Phases
 	r.Phases.Register(
 		"Dependency",
-		phases.DependencyPhase,
+		dependencies.OCMLogForwarderDependency,
 		phases.CreateEvent,
 		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
 	)
 
+	r.Phases.Register(
+		"Validate-Secrets",
+		secrets.OCMLogForwarderValidate,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Provision-ElasticSearch",
+		elasticsearch.OCMLogForwarderProvision,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Resolve-Image",
+		resolver.OCMLogForwarderResolveImage,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Suspend",
+		suspend.OCMLogForwarderSuspend,
+		phases.CreateEvent,
+	)
+
 	r.Phases.Register(
 		"Create-Resources",
 		phases.CreateResourcesPhase,
 		phases.CreateEvent,
 	)
 
+	r.Phases.Register(
+		"Prune-Resources",
+		prune.OCMLogForwarderPrune,
+		phases.CreateEvent,
+	)
+
 	r.Phases.Register(
 		"Check-Ready",
 		phases.CheckReadyPhase,
@@ -56,17 +96,47 @@ func (r *OCMLogForwarderReconciler) InitializePhases() {
 	// Update Phases
 	r.Phases.Register(
 		"Dependency",
-		phases.DependencyPhase,
+		dependencies.OCMLogForwarderDependency,
 		phases.UpdateEvent,
 		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
 	)
 
+	r.Phases.Register(
+		"Validate-Secrets",
+		secrets.OCMLogForwarderValidate,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Provision-ElasticSearch",
+		elasticsearch.OCMLogForwarderProvision,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Resolve-Image",
+		resolver.OCMLogForwarderResolveImage,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Suspend",
+		suspend.OCMLogForwarderSuspend,
+		phases.UpdateEvent,
+	)
+
 	r.Phases.Register(
 		"Create-Resources",
 		phases.CreateResourcesPhase,
 		phases.UpdateEvent,
 	)
 
+	r.Phases.Register(
+		"Prune-Resources",
+		prune.OCMLogForwarderPrune,
+		phases.UpdateEvent,
+	)
+
 	r.Phases.Register(
 		"Check-Ready",
 		phases.CheckReadyPhase,
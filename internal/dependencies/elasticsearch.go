@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependencies
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
+
+// OCMLogForwarderDependency performs the logic to determine if the dependencies of a OCMLogForwarder object,
+// which are the ECK managed Elasticsearch objects referenced by its backends, are satisfied.  Unlike the
+// generic dependency phase, each dependency is retrieved by name and namespace and is checked on every
+// reconciliation so that a dependency which becomes unhealthy is reflected in the status of the object.
+func OCMLogForwarderDependency(r workload.Reconciler, req *workload.Request) (bool, error) {
+	for _, dependency := range req.Workload.GetDependencies() {
+		satisfied, err := dependencySatisfied(r, req, dependency)
+		if err != nil {
+			return false, err
+		}
+
+		if !satisfied {
+			req.Log.Info(
+				"waiting for dependency",
+				"kind", dependency.GetWorkloadGVK().Kind,
+				"namespace", dependency.GetNamespace(),
+				"name", dependency.GetName(),
+			)
+
+			req.Workload.SetDependencyStatus(false)
+
+			return false, nil
+		}
+	}
+
+	req.Workload.SetDependencyStatus(true)
+
+	return true, nil
+}
+
+// dependencySatisfied retrieves a dependency from the cluster and returns whether it is ready.  A dependency
+// which does not exist is not satisfied.
+func dependencySatisfied(r workload.Reconciler, req *workload.Request, dependency workload.Workload) (bool, error) {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(dependency.GetWorkloadGVK())
+
+	key := types.NamespacedName{Namespace: dependency.GetNamespace(), Name: dependency.GetName()}
+	if err := r.Get(req.Context, key, object); err != nil {
+		if apierrs.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to retrieve dependency [%s/%s], %w", key.Namespace, key.Name, err)
+	}
+
+	// load the retrieved object into the dependency so that it may determine its own readiness.
+	if content, ok := dependency.(runtime.Unstructured); ok {
+		content.SetUnstructuredContent(object.Object)
+	}
+
+	return dependency.GetReadyStatus(), nil
+}
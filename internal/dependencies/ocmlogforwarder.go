@@ -17,10 +17,291 @@ limitations under the License.
 package dependencies
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
 )
 
-// OCMLogForwarderCheckReady performs the logic to determine if a OCMLogForwarder object is ready.
+var ErrDeploymentFailed = errors.New("forwarder deployment failed")
+
+// revisionAnnotation is the annotation which the deployment controller sets to the revision of the pod template,
+// on a deployment and on the replica set of each revision of the deployment.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// podFailureReasons are the container waiting reasons which indicate that the forwarder will not become
+// ready without intervention, such as fixing a bad image reference or an invalid OCM token.
+var podFailureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+
+// OCMLogForwarderCheckReady performs the logic to determine if a OCMLogForwarder object is ready.  The
+// object is considered ready once the forwarder deployment for each cluster has completed its rollout, which
+// is a scale to zero while forwarding is suspended.  The forwarding status of each cluster is recorded in the
+// status of the object.
 func OCMLogForwarderCheckReady(r workload.Reconciler, req *workload.Request) (bool, error) {
+	component, err := ocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	ready := true
+	clusters := []appsv1alpha1.OCMLogForwarderStatusCluster{}
+
+	var failure error
+
+	for _, cluster := range component.GetClusters() {
+		status := appsv1alpha1.OCMLogForwarderStatusCluster{
+			ClusterId:  cluster.ClusterId,
+			Deployment: ocmlogforwarder.DeploymentName(component, cluster),
+		}
+
+		status.Polling, status.Message, err = clusterIsPolling(r, req, component.Namespace, status.Deployment)
+		if err != nil {
+			if !errors.Is(err, ErrDeploymentFailed) {
+				return false, err
+			}
+
+			if failure == nil {
+				failure = err
+			}
+		}
+
+		ready = ready && status.Polling
+
+		// a suspended forwarder deployment is ready once it has scaled to zero, but is not polling.
+		if component.Spec.Suspend && status.Polling {
+			status.Polling, status.Message = false, "forwarding is suspended"
+		}
+
+		clusters = append(clusters, status)
+	}
+
+	component.SetClustersStatus(clusters)
+
+	if failure != nil {
+		return false, failure
+	}
+
+	return ready, nil
+}
+
+// clusterIsPolling determines if the forwarder deployment for a cluster has completed its rollout, and
+// is therefore polling for service logs.  When it has not, a message with the reason is also returned.
+func clusterIsPolling(r workload.Reconciler, req *workload.Request, namespace, name string) (bool, string, error) {
+	deployment := &appsv1.Deployment{}
+
+	if err := r.Get(req.Context, client.ObjectKey{Namespace: namespace, Name: name}, deployment); err != nil {
+		if apierrs.IsNotFound(err) {
+			return false, "forwarder deployment has not been created", nil
+		}
+
+		return false, "", fmt.Errorf("unable to retrieve deployment [%s/%s], %w", namespace, name, err)
+	}
+
+	ready, err := deploymentIsReady(r, req, deployment)
+	if err != nil {
+		return false, err.Error(), err
+	}
+
+	if !ready {
+		return false, "forwarder deployment rollout is not complete", nil
+	}
+
+	return true, "", nil
+}
+
+// deploymentIsReady determines if a deployment has completed its rollout.  An error is returned if the
+// rollout has failed, so that the failing reason is surfaced in the phase condition.
+func deploymentIsReady(r workload.Reconciler, req *workload.Request, deployment *appsv1.Deployment) (bool, error) {
+	// look for a reason that the pods are failing first, as the deployment conditions will only report
+	// that the pods are unavailable rather than why they are unavailable
+	failure, err := podFailure(r, req, deployment)
+	if err != nil {
+		return false, err
+	}
+
+	if failure != "" {
+		return false, fmt.Errorf("%w [%s/%s]; %s", ErrDeploymentFailed, deployment.Namespace, deployment.Name, failure)
+	}
+
+	progressing := deploymentCondition(deployment, appsv1.DeploymentProgressing)
+	if progressing != nil && progressing.Reason == "ProgressDeadlineExceeded" {
+		return false, fmt.Errorf("%w [%s/%s]; %s", ErrDeploymentFailed, deployment.Namespace, deployment.Name, progressing.Message)
+	}
+
+	reason := rolloutPending(deployment)
+	if reason != "" {
+		req.Log.V(2).Info(
+			"forwarder deployment rollout is not complete",
+			"deployment", deployment.Name,
+			"reason", reason,
+		)
+
+		return false, nil
+	}
+
 	return true, nil
 }
+
+// rolloutPending returns the reason that a deployment rollout is still pending, or an empty string if
+// the rollout is complete.  This follows the same logic as the 'kubectl rollout status' command.
+func rolloutPending(deployment *appsv1.Deployment) string {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return "waiting for deployment spec update to be observed"
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	switch {
+	case deployment.Status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("%d out of %d new replicas have been updated", deployment.Status.UpdatedReplicas, replicas)
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		return fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	case deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas:
+		return fmt.Sprintf("%d of %d updated replicas are available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+
+	available := deploymentCondition(deployment, appsv1.DeploymentAvailable)
+	if available == nil || available.Status != corev1.ConditionTrue {
+		return "waiting for deployment to become available"
+	}
+
+	return ""
+}
+
+// podFailure returns a message describing why a pod of the current replica set of a deployment is failing, or
+// an empty string if no pods are failing.  The pods of previous replica sets, which also match the selector of
+// the deployment while they are pending termination, are ignored.
+func podFailure(r workload.Reconciler, req *workload.Request, deployment *appsv1.Deployment) (string, error) {
+	if deployment.Spec.Selector == nil {
+		return "", nil
+	}
+
+	replicaSet, err := currentReplicaSet(r, req, deployment)
+	if err != nil || replicaSet == nil {
+		return "", err
+	}
+
+	labels := map[string]string{}
+	for key, value := range deployment.Spec.Selector.MatchLabels {
+		labels[key] = value
+	}
+
+	labels[appsv1.DefaultDeploymentUniqueLabelKey] = replicaSet.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+
+	pods := &corev1.PodList{}
+
+	if err := r.List(
+		req.Context,
+		pods,
+		client.InNamespace(deployment.Namespace),
+		client.MatchingLabels(labels),
+	); err != nil {
+		return "", fmt.Errorf("unable to list pods for deployment [%s/%s], %w", deployment.Namespace, deployment.Name, err)
+	}
+
+	for i := range pods.Items {
+		if !metav1.IsControlledBy(&pods.Items[i], replicaSet) {
+			continue
+		}
+
+		if failure := containerFailure(&pods.Items[i]); failure != "" {
+			return failure, nil
+		}
+	}
+
+	return "", nil
+}
+
+// currentReplicaSet returns the replica set of a deployment for the current revision of the deployment, or nil
+// if the replica set has not been created yet.
+func currentReplicaSet(r workload.Reconciler, req *workload.Request, deployment *appsv1.Deployment) (*appsv1.ReplicaSet, error) {
+	revision := deployment.Annotations[revisionAnnotation]
+	if revision == "" {
+		return nil, nil
+	}
+
+	replicaSets := &appsv1.ReplicaSetList{}
+
+	if err := r.List(
+		req.Context,
+		replicaSets,
+		client.InNamespace(deployment.Namespace),
+		client.MatchingLabels(deployment.Spec.Selector.MatchLabels),
+	); err != nil {
+		return nil, fmt.Errorf("unable to list replica sets for deployment [%s/%s], %w", deployment.Namespace, deployment.Name, err)
+	}
+
+	for i := range replicaSets.Items {
+		replicaSet := &replicaSets.Items[i]
+
+		if metav1.IsControlledBy(replicaSet, deployment) && replicaSet.Annotations[revisionAnnotation] == revision {
+			return replicaSet, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// containerFailure returns a message describing why a container of a pod is failing, or an empty string
+// if no containers of the pod are waiting with one of the podFailureReasons.
+func containerFailure(pod *corev1.Pod) string {
+	statuses := []corev1.ContainerStatus{}
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, containerStatus := range statuses {
+		waiting := containerStatus.State.Waiting
+		if waiting == nil || !podFailureReasons[waiting.Reason] {
+			continue
+		}
+
+		message := fmt.Sprintf(
+			"container [%s] in pod [%s] is waiting with reason [%s]",
+			containerStatus.Name,
+			pod.Name,
+			waiting.Reason,
+		)
+
+		if waiting.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, waiting.Message)
+		}
+
+		return message
+	}
+
+	return ""
+}
+
+// deploymentCondition returns the condition of a given type from a deployment, or nil if the
+// condition is not present.
+func deploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range deployment.Status.Conditions {
+		if deployment.Status.Conditions[i].Type == conditionType {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
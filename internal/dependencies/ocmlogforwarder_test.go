@@ -0,0 +1,285 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependencies
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/fakereconciler"
+)
+
+func TestContainerFailure(t *testing.T) {
+	waiting := func(name, reason, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		init     []corev1.ContainerStatus
+		statuses []corev1.ContainerStatus
+		expected string
+	}{
+		{name: "no statuses"},
+		{
+			name: "running",
+			statuses: []corev1.ContainerStatus{
+				{Name: "forwarder", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+		{
+			name:     "container creating is not a failure",
+			statuses: []corev1.ContainerStatus{waiting("forwarder", "ContainerCreating", "")},
+		},
+		{
+			name:     "crash loop",
+			statuses: []corev1.ContainerStatus{waiting("forwarder", "CrashLoopBackOff", "back-off 5m0s restarting failed container")},
+			expected: "container [forwarder] in pod [forwarder-abc] is waiting with reason [CrashLoopBackOff]: " +
+				"back-off 5m0s restarting failed container",
+		},
+		{
+			name:     "image pull without message",
+			statuses: []corev1.ContainerStatus{waiting("forwarder", "ImagePullBackOff", "")},
+			expected: "container [forwarder] in pod [forwarder-abc] is waiting with reason [ImagePullBackOff]",
+		},
+		{
+			name:     "invalid image name",
+			statuses: []corev1.ContainerStatus{waiting("forwarder", "InvalidImageName", "")},
+			expected: "container [forwarder] in pod [forwarder-abc] is waiting with reason [InvalidImageName]",
+		},
+		{
+			name:     "missing secret",
+			statuses: []corev1.ContainerStatus{waiting("forwarder", "CreateContainerConfigError", `secret "ocm-token" not found`)},
+			expected: "container [forwarder] in pod [forwarder-abc] is waiting with reason [CreateContainerConfigError]: " +
+				`secret "ocm-token" not found`,
+		},
+		{
+			name:     "init container is reported first",
+			init:     []corev1.ContainerStatus{waiting("init", "ErrImagePull", "")},
+			statuses: []corev1.ContainerStatus{waiting("forwarder", "CrashLoopBackOff", "")},
+			expected: "container [init] in pod [forwarder-abc] is waiting with reason [ErrImagePull]",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "forwarder-abc"},
+				Status: corev1.PodStatus{
+					InitContainerStatuses: tt.init,
+					ContainerStatuses:     tt.statuses,
+				},
+			}
+
+			if found := containerFailure(pod); found != tt.expected {
+				t.Errorf("expected [%s]; found [%s]", tt.expected, found)
+			}
+		})
+	}
+}
+
+func TestRolloutPending(t *testing.T) {
+	two := int32(2)
+	zero := int32(0)
+
+	available := []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}}
+
+	for _, tt := range []struct {
+		name       string
+		generation int64
+		replicas   *int32
+		status     appsv1.DeploymentStatus
+		expected   string
+	}{
+		{
+			name:       "spec update not observed",
+			generation: 2,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+			expected:   "waiting for deployment spec update to be observed",
+		},
+		{
+			name:       "replicas default to one",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+			expected:   "0 out of 1 new replicas have been updated",
+		},
+		{
+			name:       "replicas not updated",
+			generation: 1,
+			replicas:   &two,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1},
+			expected:   "1 out of 2 new replicas have been updated",
+		},
+		{
+			name:       "old replicas pending termination",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 1},
+			expected:   "1 old replicas are pending termination",
+		},
+		{
+			name:       "updated replicas not available",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1},
+			expected:   "0 of 1 updated replicas are available",
+		},
+		{
+			name:       "available condition missing",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			expected:   "waiting for deployment to become available",
+		},
+		{
+			name:       "complete",
+			generation: 1,
+			status: appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				Replicas:           1,
+				UpdatedReplicas:    1,
+				AvailableReplicas:  1,
+				Conditions:         available,
+			},
+		},
+		{
+			name:       "scaled to zero",
+			generation: 3,
+			replicas:   &zero,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 3, Conditions: available},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: tt.generation},
+				Spec:       appsv1.DeploymentSpec{Replicas: tt.replicas},
+				Status:     tt.status,
+			}
+
+			if found := rolloutPending(deployment); found != tt.expected {
+				t.Errorf("expected [%s]; found [%s]", tt.expected, found)
+			}
+		})
+	}
+}
+
+func TestPodFailure(t *testing.T) {
+	controller := true
+
+	controlledBy := func(object metav1.Object, kind string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       kind,
+			Name:       object.GetName(),
+			UID:        object.GetUID(),
+			Controller: &controller,
+		}}
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "forwarder",
+			Namespace:   "ocm",
+			UID:         "forwarder",
+			Annotations: map[string]string{revisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "forwarder"}},
+		},
+	}
+
+	replicaSet := func(name, revision string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "forwarder-" + name,
+				Namespace: "ocm",
+				UID:       types.UID(name),
+				Labels: map[string]string{
+					"app.kubernetes.io/name":               "forwarder",
+					appsv1.DefaultDeploymentUniqueLabelKey: name,
+				},
+				Annotations:     map[string]string{revisionAnnotation: revision},
+				OwnerReferences: controlledBy(deployment, "Deployment"),
+			},
+		}
+	}
+
+	pod := func(replicaSet *appsv1.ReplicaSet, reason string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            replicaSet.Name + "-pod",
+				Namespace:       "ocm",
+				Labels:          replicaSet.Labels,
+				OwnerReferences: controlledBy(replicaSet, "ReplicaSet"),
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "forwarder",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+				}},
+			},
+		}
+	}
+
+	previous, current := replicaSet("previous", "1"), replicaSet("current", "2")
+
+	for _, tt := range []struct {
+		name     string
+		objects  []client.Object
+		expected string
+	}{
+		{
+			name:    "failing pod of a previous replica set",
+			objects: []client.Object{previous, current, pod(previous, "CrashLoopBackOff"), pod(current, "ContainerCreating")},
+		},
+		{
+			name:     "failing pod of the current replica set",
+			objects:  []client.Object{previous, current, pod(previous, "ContainerCreating"), pod(current, "ImagePullBackOff")},
+			expected: "container [forwarder] in pod [forwarder-current-pod] is waiting with reason [ImagePullBackOff]",
+		},
+		{
+			name:    "current replica set not created",
+			objects: []client.Object{previous, pod(previous, "CrashLoopBackOff")},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []client.Object{}
+			for _, object := range tt.objects {
+				objects = append(objects, object.DeepCopyObject().(client.Object))
+			}
+
+			failure, err := podFailure(fakereconciler.New(objects...), fakereconciler.NewRequest(&appsv1alpha1.OCMLogForwarder{}), deployment)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if failure != tt.expected {
+				t.Errorf("expected failure [%s]; found [%s]", tt.expected, failure)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrUnexpectedResponse = errors.New("unexpected response from elasticsearch")
+
+const (
+	// clientTimeout is the timeout for each request to ElasticSearch.
+	clientTimeout = 30 * time.Second
+
+	// maxErrorBodySize is the maximum size of a response body which is included in an error.
+	maxErrorBodySize = 1024
+)
+
+// Config is the configuration of a client for the ElasticSearch REST API.
+type Config struct {
+	// URL is the url of ElasticSearch.
+	URL string
+
+	// Username and Password are the basic authentication credentials.
+	Username string
+	Password string
+
+	// APIKey is the base64 encoded api key, which takes precedence over basic authentication.
+	APIKey string
+
+	// TLS is the tls configuration of the connection to ElasticSearch.
+	TLS *tls.Config
+}
+
+// Client is a client for the subset of the ElasticSearch REST API which is used to provision the index
+// templates and index lifecycle policies for service logs.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient returns a new client for the ElasticSearch REST API.
+func NewClient(config Config) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config.TLS
+
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   clientTimeout,
+			Transport: transport,
+		},
+	}
+}
+
+// PutIndexTemplate creates or updates a composable index template.
+func (client *Client) PutIndexTemplate(ctx context.Context, name string, template interface{}) error {
+	_, err := client.do(ctx, http.MethodPut, "/_index_template/"+url.PathEscape(name), template, http.StatusOK)
+
+	return err
+}
+
+// PutLifecyclePolicy creates or updates an index lifecycle policy.
+func (client *Client) PutLifecyclePolicy(ctx context.Context, name string, policy interface{}) error {
+	_, err := client.do(ctx, http.MethodPut, "/_ilm/policy/"+url.PathEscape(name), policy, http.StatusOK)
+
+	return err
+}
+
+// EnsureRolloverAlias bootstraps a rollover alias with an initial write index, named alias-000001, if the
+// alias does not yet exist.
+func (client *Client) EnsureRolloverAlias(ctx context.Context, alias string) error {
+	status, err := client.do(
+		ctx,
+		http.MethodHead,
+		"/_alias/"+url.PathEscape(alias),
+		nil,
+		http.StatusOK,
+		http.StatusNotFound,
+	)
+	if err != nil || status == http.StatusOK {
+		return err
+	}
+
+	index := map[string]interface{}{
+		"aliases": map[string]interface{}{
+			alias: map[string]interface{}{
+				"is_write_index": true,
+			},
+		},
+	}
+
+	_, err = client.do(ctx, http.MethodPut, "/"+url.PathEscape(alias+"-000001"), index, http.StatusOK)
+
+	return err
+}
+
+// do sends a request to ElasticSearch and returns the status code of the response.  An error is returned if
+// the status code is not one of the expected status codes.
+func (client *Client) do(
+	ctx context.Context,
+	method, path string,
+	body interface{},
+	expected ...int,
+) (int, error) {
+	var reader io.Reader
+
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("unable to marshal request body for [%s %s], %w", method, path, err)
+		}
+
+		reader = bytes.NewReader(content)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(client.config.URL, "/")+path, reader)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create request for [%s %s], %w", method, path, err)
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	if client.config.APIKey != "" {
+		request.Header.Set("Authorization", "ApiKey "+client.config.APIKey)
+	} else if client.config.Username != "" {
+		request.SetBasicAuth(client.config.Username, client.config.Password)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("unable to send request [%s %s], %w", method, path, err)
+	}
+	defer response.Body.Close()
+
+	for _, status := range expected {
+		if response.StatusCode == status {
+			return status, nil
+		}
+	}
+
+	content, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+
+	return response.StatusCode, fmt.Errorf(
+		"%w; [%s %s] returned status [%d]: %s",
+		ErrUnexpectedResponse,
+		method,
+		path,
+		response.StatusCode,
+		strings.TrimSpace(string(content)),
+	)
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordedRequest is a request which was received by the elasticsearch stand-in.
+type recordedRequest struct {
+	method        string
+	path          string
+	authorization string
+	body          map[string]interface{}
+}
+
+// standIn is an httptest stand-in for the ElasticSearch REST API which records each request and responds with
+// the status code which is configured for the method and path of the request.
+type standIn struct {
+	mutex    sync.Mutex
+	requests []recordedRequest
+	statuses map[string]int
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := recordedRequest{
+		method:        r.Method,
+		path:          r.URL.EscapedPath(),
+		authorization: r.Header.Get("Authorization"),
+	}
+
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&request.body)
+	}
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, request)
+	s.mutex.Unlock()
+
+	status, ok := s.statuses[r.Method+" "+request.path]
+	if !ok {
+		status = http.StatusOK
+	}
+
+	w.WriteHeader(status)
+
+	if status >= http.StatusBadRequest {
+		_, _ = w.Write([]byte(`{"error":{"type":"illegal_argument_exception"}}`))
+	} else {
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}
+}
+
+func newStandIn(t *testing.T, statuses map[string]int) (*standIn, *httptest.Server) {
+	t.Helper()
+
+	handler := &standIn{statuses: statuses}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return handler, server
+}
+
+func TestPutIndexTemplate(t *testing.T) {
+	handler, server := newStandIn(t, nil)
+
+	client := NewClient(Config{URL: server.URL + "/", Username: "elastic", Password: "changeme"})
+	template := map[string]interface{}{"index_patterns": []string{"ocm_service_logs-*"}}
+
+	if err := client.PutIndexTemplate(context.Background(), "ocm-service-logs", template); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(handler.requests) != 1 {
+		t.Fatalf("expected 1 request; found %d", len(handler.requests))
+	}
+
+	request := handler.requests[0]
+
+	if request.method != http.MethodPut || request.path != "/_index_template/ocm-service-logs" {
+		t.Errorf("unexpected request [%s %s]", request.method, request.path)
+	}
+
+	if !strings.HasPrefix(request.authorization, "Basic ") {
+		t.Errorf("expected basic authorization; found [%s]", request.authorization)
+	}
+
+	if _, ok := request.body["index_patterns"]; !ok {
+		t.Errorf("expected index_patterns in request body; found %v", request.body)
+	}
+}
+
+func TestPutLifecyclePolicyWithAPIKey(t *testing.T) {
+	handler, server := newStandIn(t, nil)
+
+	client := NewClient(Config{URL: server.URL, Username: "ignored", APIKey: "ZW5jb2RlZA=="})
+
+	if err := client.PutLifecyclePolicy(context.Background(), "ocm-service-logs", map[string]interface{}{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request := handler.requests[0]
+
+	if request.method != http.MethodPut || request.path != "/_ilm/policy/ocm-service-logs" {
+		t.Errorf("unexpected request [%s %s]", request.method, request.path)
+	}
+
+	if request.authorization != "ApiKey ZW5jb2RlZA==" {
+		t.Errorf("expected api key authorization; found [%s]", request.authorization)
+	}
+}
+
+func TestEnsureRolloverAlias(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		status   int
+		requests []string
+	}{
+		{
+			name:     "alias exists",
+			status:   http.StatusOK,
+			requests: []string{"HEAD /_alias/ocm-write"},
+		},
+		{
+			name:     "alias missing",
+			status:   http.StatusNotFound,
+			requests: []string{"HEAD /_alias/ocm-write", "PUT /ocm-write-000001"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, server := newStandIn(t, map[string]int{"HEAD /_alias/ocm-write": tt.status})
+
+			if err := NewClient(Config{URL: server.URL}).EnsureRolloverAlias(context.Background(), "ocm-write"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(handler.requests) != len(tt.requests) {
+				t.Fatalf("expected requests %v; found %v", tt.requests, handler.requests)
+			}
+
+			for i, request := range handler.requests {
+				if found := request.method + " " + request.path; found != tt.requests[i] {
+					t.Errorf("expected request [%s]; found [%s]", tt.requests[i], found)
+				}
+			}
+
+			if tt.status == http.StatusNotFound {
+				aliases, _ := handler.requests[1].body["aliases"].(map[string]interface{})
+				if _, ok := aliases["ocm-write"]; !ok {
+					t.Errorf("expected initial index to have alias; found %v", handler.requests[1].body)
+				}
+			}
+		})
+	}
+}
+
+func TestUnexpectedResponse(t *testing.T) {
+	_, server := newStandIn(t, map[string]int{"PUT /_index_template/ocm-service-logs": http.StatusBadRequest})
+
+	err := NewClient(Config{URL: server.URL}).PutIndexTemplate(context.Background(), "ocm-service-logs", map[string]interface{}{})
+	if !errors.Is(err, ErrUnexpectedResponse) {
+		t.Fatalf("expected ErrUnexpectedResponse; found %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "illegal_argument_exception") {
+		t.Errorf("expected error to contain response body; found %v", err)
+	}
+}
+
+func TestTLS(t *testing.T) {
+	server := httptest.NewTLSServer(&standIn{})
+	t.Cleanup(server.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	for _, tt := range []struct {
+		name    string
+		tls     *tls.Config
+		wantErr bool
+	}{
+		{
+			name:    "trusted certificate authority",
+			tls:     &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool},
+			wantErr: false,
+		},
+		{
+			name:    "untrusted certificate authority",
+			tls:     &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: x509.NewCertPool()},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewClient(Config{URL: server.URL, TLS: tt.tls}).PutIndexTemplate(context.Background(), "test", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error [%t]; found %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearch
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+)
+
+var ErrInvalidTrustMaterial = errors.New("invalid trust material")
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+
+// OCMLogForwarderProvision performs the logic to provision the index templates and index lifecycle policies
+// which are managed by a OCMLogForwarder object in ElasticSearch, prior to creating the forwarder, so that
+// service logs are never sent to an index with dynamic mappings.
+func OCMLogForwarderProvision(r workload.Reconciler, req *workload.Request) (bool, error) {
+	component, err := ocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	provisionings, err := ocmlogforwarder.ElasticSearchProvisionings(component, r, req)
+	if err != nil {
+		component.SetElasticSearchProvisionedStatus(false)
+
+		return false, err
+	}
+
+	for i := range provisionings {
+		if err := provision(r, req, component, &provisionings[i]); err != nil {
+			component.SetElasticSearchProvisionedStatus(false)
+
+			return false, fmt.Errorf("unable to provision elasticsearch for %s, %w", provisionings[i].Field, err)
+		}
+	}
+
+	component.SetElasticSearchProvisionedStatus(len(provisionings) > 0)
+
+	return true, nil
+}
+
+// provision provisions the index lifecycle policy, the index template and the rollover alias for a backend.
+// The policy is provisioned first, as the index template references it.
+func provision(
+	r workload.Reconciler,
+	req *workload.Request,
+	component *appsv1alpha1.OCMLogForwarder,
+	provisioning *ocmlogforwarder.ElasticSearchProvisioning,
+) error {
+	config, err := clientConfig(r, req, component, &provisioning.ElasticSearch)
+	if err != nil {
+		return err
+	}
+
+	client := NewClient(config)
+
+	if provisioning.Policy != nil {
+		if err := client.PutLifecyclePolicy(req.Context, provisioning.PolicyName, provisioning.Policy); err != nil {
+			return err
+		}
+	}
+
+	if err := client.PutIndexTemplate(req.Context, provisioning.TemplateName, provisioning.Template); err != nil {
+		return err
+	}
+
+	if provisioning.RolloverAlias != "" {
+		return client.EnsureRolloverAlias(req.Context, provisioning.RolloverAlias)
+	}
+
+	return nil
+}
+
+// clientConfig returns the configuration of the client for an elasticsearch backend, using the same
+// credentials and trust material as the forwarder.
+func clientConfig(
+	r workload.Reconciler,
+	req *workload.Request,
+	component *appsv1alpha1.OCMLogForwarder,
+	elasticSearch *appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch,
+) (Config, error) {
+	config := Config{
+		URL: elasticSearch.Url,
+		TLS: &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: elasticSearch.TLS.InsecureSkipVerify, //nolint:gosec // explicitly requested
+		},
+	}
+
+	// the secrets of a referenced elasticsearch are read from the secrets which are managed by ECK, as the
+	// copies in the namespace of the component are not created until after provisioning.
+	if ref := elasticSearch.ElasticsearchRef; ref.Name != "" {
+		namespace := component.GetElasticsearchRefNamespace(ref)
+
+		user, err := getSecret(r, req, namespace, ref.UserSecretName())
+		if err != nil {
+			return config, err
+		}
+
+		config.Username = appsv1alpha1.ElasticsearchRefUserKey
+		config.Password = string(user.Data[appsv1alpha1.ElasticsearchRefUserKey])
+
+		if elasticSearch.TLS.CARef.Name != "" {
+			ca, err := getSecret(r, req, namespace, ref.CASecretName())
+			if err != nil {
+				return config, err
+			}
+
+			if config.TLS.RootCAs, err = certPool(ca.Data[appsv1alpha1.ElasticsearchRefCAKey]); err != nil {
+				return config, err
+			}
+		}
+
+		return config, nil
+	}
+
+	if err := setAuth(r, req, component.Namespace, elasticSearch, &config); err != nil {
+		return config, err
+	}
+
+	return config, setTLS(r, req, component.Namespace, elasticSearch, config.TLS)
+}
+
+// setAuth sets the credentials of a client configuration from the secret of an elasticsearch backend.  The
+// shape of the secret has already been validated by the Validate-Secrets phase.
+func setAuth(
+	r workload.Reconciler,
+	req *workload.Request,
+	namespace string,
+	elasticSearch *appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch,
+	config *Config,
+) error {
+	secret, err := getSecret(r, req, namespace, elasticSearch.SecretRef)
+	if err != nil {
+		return err
+	}
+
+	if elasticSearch.AuthType == appsv1alpha1.ElasticSearchAuthTypeAPIKey {
+		if encoded := secret.Data[appsv1alpha1.ElasticSearchAPIKeyEncodedKey]; len(encoded) > 0 {
+			config.APIKey = string(encoded)
+		} else {
+			config.APIKey = base64.StdEncoding.EncodeToString([]byte(
+				string(secret.Data[appsv1alpha1.ElasticSearchAPIKeyIDKey]) + ":" +
+					string(secret.Data[appsv1alpha1.ElasticSearchAPIKeyKey]),
+			))
+		}
+
+		return nil
+	}
+
+	for username, password := range secret.Data {
+		config.Username = username
+		config.Password = string(password)
+	}
+
+	return nil
+}
+
+// setTLS sets the certificate authority and client certificate of a tls configuration from the trust
+// material of an elasticsearch backend.
+func setTLS(
+	r workload.Reconciler,
+	req *workload.Request,
+	namespace string,
+	elasticSearch *appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch,
+	tlsConfig *tls.Config,
+) error {
+	esTLS := elasticSearch.TLS
+
+	if caRef := esTLS.CARef; caRef.Name != "" {
+		var bundle []byte
+
+		if caRef.Kind == appsv1alpha1.CARefKindConfigMap {
+			configMap := &corev1.ConfigMap{}
+			if err := r.Get(req.Context, types.NamespacedName{Namespace: namespace, Name: caRef.Name}, configMap); err != nil {
+				return fmt.Errorf("unable to retrieve configmap [%s/%s], %w", namespace, caRef.Name, err)
+			}
+
+			bundle = []byte(configMap.Data[caRef.Key])
+		} else {
+			secret, err := getSecret(r, req, namespace, caRef.Name)
+			if err != nil {
+				return err
+			}
+
+			bundle = secret.Data[caRef.Key]
+		}
+
+		pool, err := certPool(bundle)
+		if err != nil {
+			return err
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if esTLS.ClientCertSecretRef != "" {
+		secret, err := getSecret(r, req, namespace, esTLS.ClientCertSecretRef)
+		if err != nil {
+			return err
+		}
+
+		certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return fmt.Errorf("%w; unable to load client certificate, %s", ErrInvalidTrustMaterial, err.Error())
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return nil
+}
+
+// getSecret retrieves a secret from the cluster.
+func getSecret(r workload.Reconciler, req *workload.Request, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(req.Context, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("unable to retrieve secret [%s/%s], %w", namespace, name, err)
+	}
+
+	return secret, nil
+}
+
+// certPool returns a certificate pool which contains the certificates of a PEM encoded bundle.
+func certPool(bundle []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("%w; certificate authority bundle contains no PEM encoded certificates", ErrInvalidTrustMaterial)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakereconciler provides a workload.Reconciler which is backed by a fake client, for testing the
+// phases and mutations of the reconciliation without an API server.
+package fakereconciler
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// Reconciler is a workload.Reconciler which is backed by a fake client.  Only the client is functional, the
+// remaining methods return zero values.
+type Reconciler struct {
+	client.Client
+}
+
+// New returns a Reconciler whose fake client is seeded with objects.
+func New(objects ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+	}
+}
+
+// NewRequest returns a request for a workload.
+func NewRequest(component workload.Workload) *workload.Request {
+	return &workload.Request{
+		Context:  context.TODO(),
+		Workload: component,
+		Log:      logr.Discard(),
+	}
+}
+
+func (r *Reconciler) GetController() controller.Controller                    { return nil }
+func (r *Reconciler) GetLogger() logr.Logger                                  { return logr.Discard() }
+func (r *Reconciler) GetResources(*workload.Request) ([]client.Object, error) { return nil, nil }
+func (r *Reconciler) GetEventRecorder() record.EventRecorder                  { return record.NewFakeRecorder(10) }
+func (r *Reconciler) GetFieldManager() string                                 { return "fakereconciler" }
+func (r *Reconciler) GetWatches() []client.Object                             { return nil }
+func (r *Reconciler) SetWatch(client.Object)                                  {}
+func (r *Reconciler) CheckReady(*workload.Request) (bool, error)              { return true, nil }
+
+func (r *Reconciler) Mutate(_ *workload.Request, object client.Object) ([]client.Object, bool, error) {
+	return []client.Object{object}, false, nil
+}
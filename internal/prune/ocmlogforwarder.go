@@ -0,0 +1,87 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+)
+
+// OCMLogForwarderPrune performs the logic to delete the forwarder deployments of a OCMLogForwarder object which
+// are no longer desired, such as the deployment of a cluster which was removed from the .spec.ocm.clusters
+// field, or the single deployment for the .spec.ocm.clusterId field after moving to the .spec.ocm.clusters
+// field.  Only deployments which carry the labels of the forwarder and are owned by the object are deleted.
+func OCMLogForwarderPrune(r workload.Reconciler, req *workload.Request) (bool, error) {
+	component, err := ocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	desired := map[string]bool{}
+	for _, cluster := range component.GetClusters() {
+		desired[ocmlogforwarder.DeploymentName(component, cluster)] = true
+	}
+
+	deployments := &appsv1.DeploymentList{}
+
+	if err := r.List(
+		req.Context,
+		deployments,
+		client.InNamespace(component.Namespace),
+		client.MatchingLabels{"app.kubernetes.io/name": component.Name},
+	); err != nil {
+		return false, fmt.Errorf("unable to list deployments in namespace [%s], %w", component.Namespace, err)
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+
+		if desired[deployment.Name] || !isOwnedBy(deployment, component) {
+			continue
+		}
+
+		req.Log.Info("deleting forwarder deployment which is no longer desired", "deployment", deployment.Name)
+
+		if err := r.Delete(req.Context, deployment); err != nil && !apierrs.IsNotFound(err) {
+			return false, fmt.Errorf("unable to delete deployment [%s/%s], %w", deployment.Namespace, deployment.Name, err)
+		}
+	}
+
+	return true, nil
+}
+
+// isOwnedBy determines if a deployment is owned by a component.
+func isOwnedBy(deployment *appsv1.Deployment, component *appsv1alpha1.OCMLogForwarder) bool {
+	if component.UID == "" {
+		return false
+	}
+
+	for _, owner := range deployment.OwnerReferences {
+		if owner.UID == component.UID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/fakereconciler"
+)
+
+func TestOCMLogForwarderPrune(t *testing.T) {
+	const owner = types.UID("owner-uid")
+
+	deployment := func(name string, labels map[string]string, ownerUID types.UID) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ocm", Labels: labels},
+		}
+
+		if ownerUID != "" {
+			deployment.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: "apps.dustinscott.io/v1alpha1", Kind: "OCMLogForwarder", Name: "forwarder", UID: ownerUID},
+			}
+		}
+
+		return deployment
+	}
+
+	forwarderLabels := func(clusterID string) map[string]string {
+		labels := map[string]string{"app.kubernetes.io/name": "forwarder"}
+		if clusterID != "" {
+			labels[ocmlogforwarder.ClusterIDLabel] = clusterID
+		}
+
+		return labels
+	}
+
+	for _, tt := range []struct {
+		name        string
+		ocm         appsv1alpha1.OCMLogForwarderSpecOcm
+		deployments []client.Object
+		expected    []string
+	}{
+		{
+			name: "all desired",
+			ocm:  appsv1alpha1.OCMLogForwarderSpecOcm{Clusters: []appsv1alpha1.OCMLogForwarderSpecOcmCluster{{ClusterId: "a"}, {ClusterId: "b"}}},
+			deployments: []client.Object{
+				deployment("forwarder-a", forwarderLabels("a"), owner),
+				deployment("forwarder-b", forwarderLabels("b"), owner),
+			},
+			expected: []string{"forwarder-a", "forwarder-b"},
+		},
+		{
+			name: "cluster removed",
+			ocm:  appsv1alpha1.OCMLogForwarderSpecOcm{Clusters: []appsv1alpha1.OCMLogForwarderSpecOcmCluster{{ClusterId: "a"}}},
+			deployments: []client.Object{
+				deployment("forwarder-a", forwarderLabels("a"), owner),
+				deployment("forwarder-b", forwarderLabels("b"), owner),
+			},
+			expected: []string{"forwarder-a"},
+		},
+		{
+			name: "moved from clusterId to clusters",
+			ocm:  appsv1alpha1.OCMLogForwarderSpecOcm{Clusters: []appsv1alpha1.OCMLogForwarderSpecOcmCluster{{ClusterId: "a"}}},
+			deployments: []client.Object{
+				deployment("forwarder", forwarderLabels(""), owner),
+				deployment("forwarder-a", forwarderLabels("a"), owner),
+			},
+			expected: []string{"forwarder-a"},
+		},
+		{
+			name: "moved from clusters to clusterId",
+			ocm:  appsv1alpha1.OCMLogForwarderSpecOcm{ClusterId: "a"},
+			deployments: []client.Object{
+				deployment("forwarder", forwarderLabels(""), owner),
+				deployment("forwarder-a", forwarderLabels("a"), owner),
+			},
+			expected: []string{"forwarder"},
+		},
+		{
+			name: "not owned",
+			ocm:  appsv1alpha1.OCMLogForwarderSpecOcm{ClusterId: "a"},
+			deployments: []client.Object{
+				deployment("forwarder", forwarderLabels(""), owner),
+				deployment("forwarder-b", forwarderLabels("b"), "other-uid"),
+				deployment("forwarder-c", forwarderLabels("c"), ""),
+			},
+			expected: []string{"forwarder", "forwarder-b", "forwarder-c"},
+		},
+		{
+			name: "not labeled",
+			ocm:  appsv1alpha1.OCMLogForwarderSpecOcm{ClusterId: "a"},
+			deployments: []client.Object{
+				deployment("forwarder", forwarderLabels(""), owner),
+				deployment("unrelated", map[string]string{"app.kubernetes.io/name": "unrelated"}, owner),
+			},
+			expected: []string{"forwarder", "unrelated"},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			component := &appsv1alpha1.OCMLogForwarder{
+				ObjectMeta: metav1.ObjectMeta{Name: "forwarder", Namespace: "ocm", UID: owner},
+				Spec:       appsv1alpha1.OCMLogForwarderSpec{Ocm: tt.ocm},
+			}
+
+			reconciler := fakereconciler.New(tt.deployments...)
+
+			proceed, err := OCMLogForwarderPrune(reconciler, fakereconciler.NewRequest(component))
+			if err != nil || !proceed {
+				t.Fatalf("expected to proceed without error; found [%t] [%v]", proceed, err)
+			}
+
+			deployments := &appsv1.DeploymentList{}
+			if err := reconciler.List(context.TODO(), deployments); err != nil {
+				t.Fatalf("unable to list deployments: %v", err)
+			}
+
+			found := []string{}
+			for i := range deployments.Items {
+				found = append(found, deployments.Items[i].Name)
+			}
+
+			sort.Strings(found)
+
+			if len(found) != len(tt.expected) {
+				t.Fatalf("expected deployments %v; found %v", tt.expected, found)
+			}
+
+			for i := range found {
+				if found[i] != tt.expected[i] {
+					t.Fatalf("expected deployments %v; found %v", tt.expected, found)
+				}
+			}
+		})
+	}
+}
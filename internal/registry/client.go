@@ -0,0 +1,279 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnexpectedResponse = errors.New("unexpected response from registry")
+	ErrInvalidRepository  = errors.New("invalid image repository")
+)
+
+const (
+	// clientTimeout is the timeout for each request to the registry.
+	clientTimeout = 30 * time.Second
+
+	// maxErrorBodySize is the maximum size of a response body which is included in an error.
+	maxErrorBodySize = 1024
+
+	// maxManifestSize is the maximum size of a manifest which is read to compute its digest.
+	maxManifestSize = 4 * 1024 * 1024
+
+	// dockerHubHost is the host of the Docker Hub registry API, which is the registry of repositories
+	// without a registry host.
+	dockerHubHost = "registry-1.docker.io"
+)
+
+// manifestMediaTypes are the media types of the manifests which are accepted when resolving a digest.  Image
+// indexes are preferred, so that the digest refers to the same image on every node architecture.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// digest is the expression which a digest returned by the registry must match.
+var digest = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// challengeParameter is the expression which matches a parameter of a bearer authentication challenge.
+var challengeParameter = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Config is the configuration of a client for the registry API.
+type Config struct {
+	// URL is the url of the registry, such as 'https://ghcr.io'.
+	URL string
+}
+
+// Client is a client for the subset of the OCI distribution API which is used to resolve the tag of an image
+// to a digest.  Only anonymous access, including anonymous bearer tokens, is supported.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient returns a new client for the registry API.
+func NewClient(config Config) *Client {
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: clientTimeout,
+		},
+	}
+}
+
+// ParseRepository splits an image repository, such as 'ghcr.io/scottd018/ocm-log-forwarder', into the url of
+// its registry and the name of the repository within the registry.  Repositories without a registry host are
+// Docker Hub repositories.  Registries on a loopback host are accessed over http, as local registries
+// commonly are.
+func ParseRepository(image string) (registryURL, repository string, err error) {
+	host, name := "", image
+
+	if i := strings.Index(image, "/"); i > 0 {
+		if first := image[:i]; strings.ContainsAny(first, ".:") || first == "localhost" {
+			host, name = first, image[i+1:]
+		}
+	}
+
+	if name == "" || strings.ContainsAny(name, ":@") {
+		return "", "", fmt.Errorf("%w [%s]; expected a repository without a tag or digest", ErrInvalidRepository, image)
+	}
+
+	if host == "" || host == "docker.io" {
+		host = dockerHubHost
+
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+
+	scheme := "https"
+	if hostname := strings.Split(host, ":")[0]; hostname == "localhost" || hostname == "127.0.0.1" {
+		scheme = "http"
+	}
+
+	return scheme + "://" + host, name, nil
+}
+
+// Resolve returns the digest of the manifest of a repository which a tag refers to.
+func (client *Client) Resolve(ctx context.Context, repository, tag string) (string, error) {
+	path := "/v2/" + repository + "/manifests/" + url.PathEscape(tag)
+
+	response, err := client.do(ctx, http.MethodHead, path, "")
+	if err != nil {
+		return "", err
+	}
+
+	// the registry may require a token, even for anonymous access, in which case a token is requested from
+	// the realm of the challenge and the request is retried.
+	if response.StatusCode == http.StatusUnauthorized {
+		token, err := client.token(ctx, response.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+
+		if response, err = client.do(ctx, http.MethodHead, path, token); err != nil {
+			return "", err
+		}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", unexpectedResponse(http.MethodHead, path, response)
+	}
+
+	if resolved := response.Header.Get("Docker-Content-Digest"); digest.MatchString(resolved) {
+		return resolved, nil
+	}
+
+	// the digest header is optional, so the digest is computed from the manifest when it is not returned.
+	return client.manifestDigest(ctx, path, response.Request.Header.Get("Authorization"))
+}
+
+// manifestDigest retrieves a manifest and returns its digest.
+func (client *Client) manifestDigest(ctx context.Context, path, authorization string) (string, error) {
+	response, err := client.do(ctx, http.MethodGet, path, authorization)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", unexpectedResponse(http.MethodGet, path, response)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.LimitReader(response.Body, maxManifestSize)); err != nil {
+		return "", fmt.Errorf("unable to read manifest [%s], %w", path, err)
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// token requests an anonymous bearer token from the realm of a bearer authentication challenge and returns
+// the value of the authorization header which uses the token.
+func (client *Client) token(ctx context.Context, challenge string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return "", fmt.Errorf("%w; unsupported authentication challenge [%s]", ErrUnexpectedResponse, challenge)
+	}
+
+	parameters := map[string]string{}
+	for _, match := range challengeParameter.FindAllStringSubmatch(challenge, -1) {
+		parameters[match[1]] = match[2]
+	}
+
+	realm, err := url.Parse(parameters["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("%w; invalid realm in authentication challenge [%s]", ErrUnexpectedResponse, challenge)
+	}
+
+	query := realm.Query()
+
+	for _, key := range []string{"service", "scope"} {
+		if parameters[key] != "" {
+			query.Set(key, parameters[key])
+		}
+	}
+
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("unable to create token request for [%s], %w", realm.Host, err)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("unable to request token from [%s], %w", realm.Host, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", unexpectedResponse(http.MethodGet, realm.Path, response)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("unable to decode token response from [%s], %w", realm.Host, err)
+	}
+
+	if body.Token == "" {
+		body.Token = body.AccessToken
+	}
+
+	if body.Token == "" {
+		return "", fmt.Errorf("%w; token response from [%s] contains no token", ErrUnexpectedResponse, realm.Host)
+	}
+
+	return "Bearer " + body.Token, nil
+}
+
+// do sends a request for a manifest to the registry.  The body of the response must be closed by the caller
+// when the method is GET.
+func (client *Client) do(ctx context.Context, method, path, authorization string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(client.config.URL, "/")+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request for [%s %s], %w", method, path, err)
+	}
+
+	request.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request [%s %s], %w", method, path, err)
+	}
+
+	if method == http.MethodHead {
+		response.Body.Close()
+	}
+
+	return response, nil
+}
+
+// unexpectedResponse returns an error which describes a response with an unexpected status code.
+func unexpectedResponse(method, path string, response *http.Response) error {
+	content, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+
+	return fmt.Errorf(
+		"%w; [%s %s] returned status [%d]: %s",
+		ErrUnexpectedResponse,
+		method,
+		path,
+		response.StatusCode,
+		strings.TrimSpace(string(content)),
+	)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testManifest is the manifest which is served by the registry stand-in.
+const testManifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`
+
+// testToken is the bearer token which is issued by the registry stand-in.
+const testToken = "anonymous-token"
+
+// standIn is an httptest stand-in for the registry API, which serves a single manifest for each tag and
+// optionally requires an anonymous bearer token which it issues from its own token endpoint.
+type standIn struct {
+	url          string
+	tags         map[string]string
+	requireToken bool
+	omitDigest   bool
+	scopes       []string
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		s.scopes = append(s.scopes, r.URL.Query().Get("scope"))
+
+		_, _ = fmt.Fprintf(w, `{"token":%q}`, testToken)
+
+		return
+	}
+
+	if s.requireToken && r.Header.Get("Authorization") != "Bearer "+testToken {
+		w.Header().Set(
+			"WWW-Authenticate",
+			fmt.Sprintf(`Bearer realm="%s/token",service="stand-in",scope="repository:%s:pull"`, s.url, s.repository(r)),
+		)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+		w.WriteHeader(http.StatusNotAcceptable)
+
+		return
+	}
+
+	tag := r.URL.Path[strings.LastIndex(r.URL.Path, "/manifests/")+len("/manifests/"):]
+
+	manifest, ok := s.tags[tag]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"MANIFEST_UNKNOWN"}]}`))
+
+		return
+	}
+
+	if !s.omitDigest {
+		w.Header().Set("Docker-Content-Digest", manifestDigest(manifest))
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+
+	if r.Method == http.MethodGet {
+		_, _ = w.Write([]byte(manifest))
+	}
+}
+
+func (s *standIn) repository(r *http.Request) string {
+	return strings.TrimPrefix(r.URL.Path[:strings.LastIndex(r.URL.Path, "/manifests/")], "/v2/")
+}
+
+func newStandIn(t *testing.T, s *standIn) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	s.url = server.URL
+
+	return server
+}
+
+func manifestDigest(manifest string) string {
+	hash := sha256.Sum256([]byte(manifest))
+
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+func TestClientResolve(t *testing.T) {
+	expected := manifestDigest(testManifest)
+
+	for _, tt := range []struct {
+		name         string
+		tag          string
+		requireToken bool
+		omitDigest   bool
+		wantErr      bool
+	}{
+		{name: "digest header", tag: "latest"},
+		{name: "anonymous token", tag: "latest", requireToken: true},
+		{name: "digest computed from manifest", tag: "latest", omitDigest: true},
+		{name: "anonymous token and digest computed from manifest", tag: "v1.0.0", requireToken: true, omitDigest: true},
+		{name: "unknown tag", tag: "missing", wantErr: true},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &standIn{
+				tags:         map[string]string{"latest": testManifest, "v1.0.0": testManifest},
+				requireToken: tt.requireToken,
+				omitDigest:   tt.omitDigest,
+			}
+			server := newStandIn(t, handler)
+
+			resolved, err := NewClient(Config{URL: server.URL}).Resolve(context.TODO(), "scottd018/ocm-log-forwarder", tt.tag)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnexpectedResponse) {
+					t.Fatalf("expected error [%v]; found [%v]", ErrUnexpectedResponse, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resolved != expected {
+				t.Errorf("expected digest [%s]; found [%s]", expected, resolved)
+			}
+
+			if tt.requireToken {
+				if len(handler.scopes) == 0 || handler.scopes[0] != "repository:scottd018/ocm-log-forwarder:pull" {
+					t.Errorf("expected token to be requested for the pull scope of the repository; found %v", handler.scopes)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRepository(t *testing.T) {
+	for _, tt := range []struct {
+		image       string
+		registryURL string
+		repository  string
+		wantErr     bool
+	}{
+		{image: "ghcr.io/scottd018/ocm-log-forwarder", registryURL: "https://ghcr.io", repository: "scottd018/ocm-log-forwarder"},
+		{image: "registry.example.com:5000/mirror/forwarder", registryURL: "https://registry.example.com:5000", repository: "mirror/forwarder"},
+		{image: "localhost:5000/ocm-log-forwarder", registryURL: "http://localhost:5000", repository: "ocm-log-forwarder"},
+		{image: "scottd018/ocm-log-forwarder", registryURL: "https://registry-1.docker.io", repository: "scottd018/ocm-log-forwarder"},
+		{image: "docker.io/forwarder", registryURL: "https://registry-1.docker.io", repository: "library/forwarder"},
+		{image: "ghcr.io/scottd018/ocm-log-forwarder:latest", wantErr: true},
+	} {
+		registryURL, repository, err := ParseRepository(tt.image)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidRepository) {
+				t.Errorf("%s: expected error [%v]; found [%v]", tt.image, ErrInvalidRepository, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.image, err)
+
+			continue
+		}
+
+		if registryURL != tt.registryURL || repository != tt.repository {
+			t.Errorf("%s: expected [%s] [%s]; found [%s] [%s]", tt.image, tt.registryURL, tt.repository, registryURL, repository)
+		}
+	}
+}
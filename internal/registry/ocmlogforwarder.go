@@ -0,0 +1,117 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+)
+
+// Resolver resolves the requested versions of forwarders to digests.  A resolver is created once for each
+// reconciler, so that the client of each registry, and its connections, are reused by every reconciliation.
+type Resolver struct {
+	mutex   sync.Mutex
+	clients map[string]*Client
+}
+
+// NewResolver returns a new resolver of the requested versions of forwarders.
+func NewResolver() *Resolver {
+	return &Resolver{clients: map[string]*Client{}}
+}
+
+// OCMLogForwarderResolveImage performs the logic to resolve the requested version of the forwarder of a
+// OCMLogForwarder object to a digest, prior to creating the forwarder, so that every forwarder deployment runs
+// the same build of a moving tag such as 'latest'.  The version is resolved once for each requested image, and
+// again each time the value of the resolve image annotation changes, and is recorded in the status of the
+// object, which the forwarder deployment is rendered from.  When the version can not be resolved, such as when
+// the registry is unreachable, the forwarder falls back to the previously resolved image, or to the requested
+// image, and the version is not resolved again until the image resolution retry interval has passed.
+func (resolver *Resolver) OCMLogForwarderResolveImage(r workload.Reconciler, req *workload.Request) (bool, error) {
+	component, err := ocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	requested := component.GetRequestedImage()
+
+	// a version which is already a digest is pinned and does not need to be resolved.
+	if component.VersionIsDigest() {
+		component.SetResolvedImageStatus(requested)
+		component.SetImageResolutionFailureStatus("")
+
+		return true, nil
+	}
+
+	request := component.GetResolveImageRequest()
+
+	if component.GetDeploymentImage() != requested && component.GetResolveImageRequestStatus() == request {
+		return true, nil
+	}
+
+	// the reconciler requeues the object once the retry interval has passed, after which the version is resolved
+	// again.
+	if retryAfter := component.GetImageResolutionRetryAfter(time.Now()); retryAfter > 0 {
+		req.Log.V(2).Info("skipping image resolution after a failure", "image", requested, "retryAfter", retryAfter)
+
+		return true, nil
+	}
+
+	resolved, err := resolver.resolve(req.Context, component.GetImage(), component.GetVersion())
+	if err != nil {
+		req.Log.Error(err, "unable to resolve image; falling back to the deployment image", "image", component.GetDeploymentImage())
+
+		component.SetImageResolutionFailureStatus(fmt.Sprintf("unable to resolve image [%s], %s", requested, err))
+
+		return true, nil
+	}
+
+	req.Log.Info("resolved image", "image", requested, "digest", resolved)
+
+	component.SetResolvedImageStatus(requested + "@" + resolved)
+	component.SetResolveImageRequestStatus(request)
+	component.SetImageResolutionFailureStatus("")
+
+	return true, nil
+}
+
+// resolve resolves a version of an image, which is not pinned to a digest, to a digest.
+func (resolver *Resolver) resolve(ctx context.Context, image, version string) (string, error) {
+	registryURL, repository, err := ParseRepository(image)
+	if err != nil {
+		return "", err
+	}
+
+	return resolver.client(registryURL).Resolve(ctx, repository, version)
+}
+
+// client returns the client of a registry, which is created when it is first requested.
+func (resolver *Resolver) client(registryURL string) *Client {
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+
+	if _, ok := resolver.clients[registryURL]; !ok {
+		resolver.clients[registryURL] = NewClient(Config{URL: registryURL})
+	}
+
+	return resolver.clients[registryURL]
+}
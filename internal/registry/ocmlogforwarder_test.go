@@ -0,0 +1,190 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/fakereconciler"
+)
+
+func TestOCMLogForwarderResolveImage(t *testing.T) {
+	const previous = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+	current := manifestDigest(testManifest)
+
+	server := newStandIn(t, &standIn{tags: map[string]string{"latest": testManifest}})
+	image := strings.TrimPrefix(server.URL, "http://") + "/scottd018/ocm-log-forwarder"
+
+	resolver := NewResolver()
+
+	for _, tt := range []struct {
+		name            string
+		version         string
+		request         string
+		resolvedImage   string
+		resolvedRequest string
+		failedAgo       time.Duration
+		wantImage       string
+		wantRequest     string
+		wantFailure     bool
+	}{
+		{
+			name:      "unresolved",
+			version:   "latest",
+			wantImage: image + ":latest@" + current,
+		},
+		{
+			name:          "resolved",
+			version:       "latest",
+			resolvedImage: image + ":latest@" + previous,
+			wantImage:     image + ":latest@" + previous,
+		},
+		{
+			name:          "resolved for another version",
+			version:       "latest",
+			resolvedImage: image + ":v1.0.0@" + previous,
+			wantImage:     image + ":latest@" + current,
+		},
+		{
+			name:          "re-resolve requested",
+			version:       "latest",
+			request:       "2023-06-01T00:00:00Z",
+			resolvedImage: image + ":latest@" + previous,
+			wantImage:     image + ":latest@" + current,
+			wantRequest:   "2023-06-01T00:00:00Z",
+		},
+		{
+			name:            "re-resolve already performed",
+			version:         "latest",
+			request:         "2023-06-01T00:00:00Z",
+			resolvedImage:   image + ":latest@" + previous,
+			resolvedRequest: "2023-06-01T00:00:00Z",
+			wantImage:       image + ":latest@" + previous,
+			wantRequest:     "2023-06-01T00:00:00Z",
+		},
+		{
+			name:        "unresolvable falls back to the requested image",
+			version:     "missing",
+			wantImage:   image + ":missing",
+			wantFailure: true,
+		},
+		{
+			name:          "unresolvable re-resolve keeps the resolved image",
+			version:       "missing",
+			request:       "2023-06-01T00:00:00Z",
+			resolvedImage: image + ":missing@" + previous,
+			wantImage:     image + ":missing@" + previous,
+			wantFailure:   true,
+		},
+		{
+			name:        "unresolvable within the retry interval is not resolved again",
+			version:     "latest",
+			failedAgo:   time.Minute,
+			wantImage:   image + ":latest",
+			wantFailure: true,
+		},
+		{
+			name:      "unresolvable after the retry interval is resolved again",
+			version:   "latest",
+			failedAgo: appsv1alpha1.ImageResolutionRetryInterval + time.Minute,
+			wantImage: image + ":latest@" + current,
+		},
+		{
+			name:          "digest",
+			version:       current,
+			resolvedImage: image + ":latest@" + previous,
+			wantImage:     image + "@" + current,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			component := &appsv1alpha1.OCMLogForwarder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "forwarder",
+					Namespace:   "ocm",
+					Annotations: map[string]string{appsv1alpha1.ResolveImageAnnotation: tt.request},
+				},
+				Spec: appsv1alpha1.OCMLogForwarderSpec{Image: image, Version: tt.version},
+				Status: appsv1alpha1.OCMLogForwarderStatus{
+					ResolvedImage:       tt.resolvedImage,
+					ResolveImageRequest: tt.resolvedRequest,
+				},
+			}
+
+			if tt.failedAgo != 0 {
+				failed := metav1.NewTime(time.Now().Add(-tt.failedAgo))
+
+				component.Status.ImageResolutionFailure = "unable to resolve image"
+				component.Status.ImageResolutionFailureTime = &failed
+			}
+
+			proceed, err := resolver.OCMLogForwarderResolveImage(fakereconciler.New(), fakereconciler.NewRequest(component))
+			if err != nil || !proceed {
+				t.Fatalf("expected phase to proceed; found proceed [%t] with error [%v]", proceed, err)
+			}
+
+			if image := component.GetDeploymentImage(); image != tt.wantImage {
+				t.Errorf("expected deployment image [%s]; found [%s]", tt.wantImage, image)
+			}
+
+			if request := component.GetResolveImageRequestStatus(); request != tt.wantRequest {
+				t.Errorf("expected resolve image request [%s]; found [%s]", tt.wantRequest, request)
+			}
+
+			component.SetPhaseCondition(&status.PhaseCondition{Phase: "Resolve-Image", State: status.PhaseStateComplete})
+
+			condition := component.Status.Conditions[0]
+
+			if condition.Type != appsv1alpha1.ConditionTypeImageResolved {
+				t.Errorf("expected condition type [%s]; found [%s]", appsv1alpha1.ConditionTypeImageResolved, condition.Type)
+			}
+
+			if !tt.wantFailure {
+				if failure := component.GetImageResolutionFailureStatus(); failure != "" || condition.Status != metav1.ConditionTrue {
+					t.Errorf("expected image to be resolved; found condition [%s] with failure [%s]", condition.Status, failure)
+				}
+
+				return
+			}
+
+			if condition.Status != metav1.ConditionFalse || condition.Reason != appsv1alpha1.ConditionReasonImageNotResolved {
+				t.Errorf("expected condition [%s] with reason [%s]; found [%s] with reason [%s]",
+					metav1.ConditionFalse, appsv1alpha1.ConditionReasonImageNotResolved, condition.Status, condition.Reason)
+			}
+
+			if condition.Message == "" || condition.Message != component.GetImageResolutionFailureStatus() {
+				t.Errorf("expected condition message to be the resolution failure; found [%s]", condition.Message)
+			}
+
+			if retryAfter := component.GetImageResolutionRetryAfter(time.Now()); retryAfter <= 0 {
+				t.Errorf("expected image resolution to be retried after the retry interval; found [%s]", retryAfter)
+			}
+		})
+	}
+
+	if len(resolver.clients) != 1 {
+		t.Errorf("expected the client of the registry to be reused; found [%d] clients", len(resolver.clients))
+	}
+}
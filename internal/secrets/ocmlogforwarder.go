@@ -0,0 +1,457 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+)
+
+var ErrInvalidSecret = errors.New("invalid secret")
+
+// secretCheck is a check which validates the shape of a single secret.
+type secretCheck struct {
+	// name is the name of the secret in the namespace of the workload.
+	name string
+
+	// field is the field of the workload which references the secret.
+	field string
+
+	// validate validates the contents of the secret.
+	validate func(*corev1.Secret) error
+}
+
+// OCMLogForwarderValidate performs the logic to validate the secrets which are referenced by a OCMLogForwarder
+// object prior to creating the child resources, so that a missing or malformed secret does not result in
+// a broken forwarder pod.
+func OCMLogForwarderValidate(r workload.Reconciler, req *workload.Request) (bool, error) {
+	component, err := ocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	for _, check := range secretChecks(component) {
+		if err := validateSecret(r, req, component.Namespace, check); err != nil {
+			component.SetSecretsValidStatus(false)
+
+			return false, err
+		}
+	}
+
+	component.SetSecretsValidStatus(true)
+
+	return true, nil
+}
+
+// secretChecks returns the checks for each of the secrets which are referenced by the component.
+func secretChecks(component *appsv1alpha1.OCMLogForwarder) []secretCheck {
+	checks := ocmSecretChecks(component)
+
+	for _, backend := range component.GetBackends() {
+		if check, ok := backendSecretCheck(backend); ok {
+			checks = append(checks, check)
+		}
+
+		if backend.Type == appsv1alpha1.BackendTypeElasticSearch {
+			checks = append(checks, elasticSearchTLSSecretChecks(backend)...)
+		}
+	}
+
+	return checks
+}
+
+// ocmSecretChecks returns the checks for each of the secrets which contain the OCM tokens of the clusters.  A
+// secret which is shared by multiple clusters must contain a token for each of those clusters.
+func ocmSecretChecks(component *appsv1alpha1.OCMLogForwarder) []secretCheck {
+	field := ".spec.ocm.secretRef"
+	if len(component.Spec.Ocm.Clusters) > 0 {
+		field = ".spec.ocm.clusters.secretRef"
+	}
+
+	secretRefs := []string{}
+	clusterIDs := map[string][]string{}
+
+	for _, cluster := range component.GetClusters() {
+		if _, found := clusterIDs[cluster.SecretRef]; !found {
+			secretRefs = append(secretRefs, cluster.SecretRef)
+		}
+
+		clusterIDs[cluster.SecretRef] = append(clusterIDs[cluster.SecretRef], cluster.ClusterId)
+	}
+
+	checks := make([]secretCheck, len(secretRefs))
+
+	for i, secretRef := range secretRefs {
+		ids := clusterIDs[secretRef]
+
+		checks[i] = secretCheck{
+			name:  secretRef,
+			field: field,
+			validate: func(secret *corev1.Secret) error {
+				return validateOCMTokens(secret, ids)
+			},
+		}
+
+		if component.Spec.Ocm.AuthType == appsv1alpha1.OCMAuthTypeServiceAccount {
+			checks[i].validate = validateOCMServiceAccount
+		}
+	}
+
+	return checks
+}
+
+// backendSecretCheck returns the check for the secret which is referenced by a backend.  It returns false if
+// the backend does not reference a secret.
+func backendSecretCheck(backend appsv1alpha1.OCMLogForwarderSpecNamedBackend) (secretCheck, bool) {
+	field := backend.GetFieldPath()
+
+	switch backend.Type {
+	case appsv1alpha1.BackendTypeElasticSearch:
+		// the credentials of a referenced elasticsearch are copied from the secret which is managed by ECK.
+		if backend.ElasticSearch.ElasticsearchRef.Name != "" {
+			return secretCheck{}, false
+		}
+
+		return secretCheck{
+			name:  backend.ElasticSearch.SecretRef,
+			field: field + ".elasticSearch.secretRef",
+			validate: func(secret *corev1.Secret) error {
+				return validateElasticSearchAuth(secret, backend.ElasticSearch.AuthType)
+			},
+		}, true
+	case appsv1alpha1.BackendTypeSplunk:
+		return secretCheck{
+			name:     backend.Splunk.SecretRef,
+			field:    field + ".splunk.secretRef",
+			validate: validateSplunkToken,
+		}, true
+	case appsv1alpha1.BackendTypeLoki:
+		if backend.Loki.AuthType == appsv1alpha1.LokiAuthTypeNone {
+			return secretCheck{}, false
+		}
+
+		return secretCheck{
+			name:  backend.Loki.SecretRef,
+			field: field + ".loki.secretRef",
+			validate: func(secret *corev1.Secret) error {
+				return validateLokiAuth(secret, backend.Loki.AuthType)
+			},
+		}, true
+	case appsv1alpha1.BackendTypeKafka:
+		if backend.Kafka.AuthType == appsv1alpha1.KafkaAuthTypeNone {
+			return secretCheck{}, false
+		}
+
+		return secretCheck{
+			name:  backend.Kafka.SecretRef,
+			field: field + ".kafka.secretRef",
+			validate: func(secret *corev1.Secret) error {
+				return validateKafkaAuth(secret, backend.Kafka.AuthType)
+			},
+		}, true
+	case appsv1alpha1.BackendTypeS3:
+		return secretCheck{
+			name:     backend.S3.SecretRef,
+			field:    field + ".s3.secretRef",
+			validate: validateS3Credentials,
+		}, true
+	}
+
+	return secretCheck{}, false
+}
+
+// elasticSearchTLSSecretChecks returns the checks for the secrets which contain the trust material of an
+// elasticsearch backend.
+func elasticSearchTLSSecretChecks(backend appsv1alpha1.OCMLogForwarderSpecNamedBackend) []secretCheck {
+	field := backend.GetFieldPath() + ".elasticSearch.tls"
+	esTLS := backend.ElasticSearch.TLS
+	checks := []secretCheck{}
+
+	// the certificate authority of a referenced elasticsearch is copied from the secret which is managed by ECK.
+	if esTLS.CARef.Kind == appsv1alpha1.CARefKindSecret && esTLS.CARef.Name != "" &&
+		backend.ElasticSearch.ElasticsearchRef.Name == "" {
+		checks = append(checks, secretCheck{
+			name:  esTLS.CARef.Name,
+			field: field + ".caRef",
+			validate: func(secret *corev1.Secret) error {
+				return validateCABundle(secret, esTLS.CARef.Key)
+			},
+		})
+	}
+
+	if esTLS.ClientCertSecretRef != "" {
+		checks = append(checks, secretCheck{
+			name:     esTLS.ClientCertSecretRef,
+			field:    field + ".clientCertSecretRef",
+			validate: validateClientCert,
+		})
+	}
+
+	return checks
+}
+
+// validateSecret retrieves a secret from the cluster and runs a check against it.
+func validateSecret(r workload.Reconciler, req *workload.Request, namespace string, check secretCheck) error {
+	if check.name == "" {
+		return fmt.Errorf("%w; %s must be set", ErrInvalidSecret, check.field)
+	}
+
+	secret := &corev1.Secret{}
+
+	if err := r.Get(req.Context, types.NamespacedName{Name: check.name, Namespace: namespace}, secret); err != nil {
+		if apierrs.IsNotFound(err) {
+			return fmt.Errorf("%w; secret [%s/%s] from %s does not exist", ErrInvalidSecret, namespace, check.name, check.field)
+		}
+
+		return fmt.Errorf("unable to retrieve secret [%s/%s], %w", namespace, check.name, err)
+	}
+
+	if err := check.validate(secret); err != nil {
+		return fmt.Errorf("%w; secret [%s/%s] from %s %s", ErrInvalidSecret, namespace, check.name, check.field, err.Error())
+	}
+
+	return nil
+}
+
+// validateOCMTokens validates that a secret contains exactly one key for each of the cluster ids, each with
+// a value of the OCM token json.
+func validateOCMTokens(secret *corev1.Secret, clusterIDs []string) error {
+	keys := secretKeys(secret)
+
+	expected := append([]string{}, clusterIDs...)
+	sort.Strings(expected)
+
+	if strings.Join(keys, ",") != strings.Join(expected, ",") {
+		return fmt.Errorf(
+			"must contain exactly one key matching each clusterId [%s]; found keys [%s]",
+			strings.Join(expected, ", "),
+			strings.Join(keys, ", "),
+		)
+	}
+
+	for _, clusterID := range expected {
+		token := map[string]interface{}{}
+
+		if err := json.Unmarshal(secret.Data[clusterID], &token); err != nil {
+			return fmt.Errorf("must contain valid token json at key [%s]; %s", clusterID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// validateOCMServiceAccount validates that a secret contains the client id and client secret of a service
+// account for the 'serviceAccount' authentication type.
+func validateOCMServiceAccount(secret *corev1.Secret) error {
+	for _, key := range []string{appsv1alpha1.OCMClientIDKey, appsv1alpha1.OCMClientSecretKey} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf(
+				"must contain a non-empty value at key [%s] for authType [%s]; found keys [%s]",
+				key,
+				appsv1alpha1.OCMAuthTypeServiceAccount,
+				strings.Join(secretKeys(secret), ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateElasticSearchAuth validates that a secret contains the authentication information required
+// by an elasticsearch authentication type.
+func validateElasticSearchAuth(secret *corev1.Secret, authType string) error {
+	keys := secretKeys(secret)
+
+	switch authType {
+	case appsv1alpha1.ElasticSearchAuthTypeBasic:
+		if len(keys) != 1 {
+			return fmt.Errorf(
+				"must contain exactly one key/value pair of username/password for authType [basic]; found keys [%s]",
+				strings.Join(keys, ", "),
+			)
+		}
+
+		if len(secret.Data[keys[0]]) == 0 {
+			return fmt.Errorf("must contain a password for user [%s] for authType [basic]", keys[0])
+		}
+	case appsv1alpha1.ElasticSearchAuthTypeAPIKey:
+		encoded := len(secret.Data[appsv1alpha1.ElasticSearchAPIKeyEncodedKey]) > 0
+		pair := len(secret.Data[appsv1alpha1.ElasticSearchAPIKeyIDKey]) > 0 &&
+			len(secret.Data[appsv1alpha1.ElasticSearchAPIKeyKey]) > 0
+
+		if encoded == pair {
+			return fmt.Errorf(
+				"must contain either an encoded api key at key [%s] or an api key id and api key at keys [%s, %s] "+
+					"for authType [apikey]; found keys [%s]",
+				appsv1alpha1.ElasticSearchAPIKeyEncodedKey,
+				appsv1alpha1.ElasticSearchAPIKeyIDKey,
+				appsv1alpha1.ElasticSearchAPIKeyKey,
+				strings.Join(keys, ", "),
+			)
+		}
+	default:
+		return fmt.Errorf("has unsupported authType [%s]", authType)
+	}
+
+	return nil
+}
+
+// validateSplunkToken validates that a secret contains a splunk http event collector token.
+func validateSplunkToken(secret *corev1.Secret) error {
+	if len(secret.Data["token"]) == 0 {
+		return fmt.Errorf(
+			"must contain a splunk http event collector token at key [token]; found keys [%s]",
+			strings.Join(secretKeys(secret), ", "),
+		)
+	}
+
+	return nil
+}
+
+// validateLokiAuth validates that a secret contains the authentication information required by a loki
+// authentication type.
+func validateLokiAuth(secret *corev1.Secret, authType string) error {
+	keys := secretKeys(secret)
+
+	switch authType {
+	case appsv1alpha1.LokiAuthTypeBasic:
+		if len(keys) != 1 {
+			return fmt.Errorf(
+				"must contain exactly one key/value pair of username/password for authType [basic]; found keys [%s]",
+				strings.Join(keys, ", "),
+			)
+		}
+
+		if len(secret.Data[keys[0]]) == 0 {
+			return fmt.Errorf("must contain a password for user [%s] for authType [basic]", keys[0])
+		}
+	case appsv1alpha1.LokiAuthTypeBearer:
+		if len(secret.Data["token"]) == 0 {
+			return fmt.Errorf(
+				"must contain a bearer token at key [token] for authType [bearer]; found keys [%s]",
+				strings.Join(keys, ", "),
+			)
+		}
+	default:
+		return fmt.Errorf("has unsupported authType [%s]", authType)
+	}
+
+	return nil
+}
+
+// validateKafkaAuth validates that a secret contains the credentials required by a kafka authentication type.
+func validateKafkaAuth(secret *corev1.Secret, authType string) error {
+	keys := secretKeys(secret)
+
+	switch authType {
+	case appsv1alpha1.KafkaAuthTypeScramSHA256, appsv1alpha1.KafkaAuthTypeScramSHA512:
+		if len(keys) != 1 {
+			return fmt.Errorf(
+				"must contain exactly one key/value pair of username/password for authType [%s]; found keys [%s]",
+				authType,
+				strings.Join(keys, ", "),
+			)
+		}
+
+		if len(secret.Data[keys[0]]) == 0 {
+			return fmt.Errorf("must contain a password for user [%s] for authType [%s]", keys[0], authType)
+		}
+	case appsv1alpha1.KafkaAuthTypeMTLS:
+		for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+			if len(secret.Data[key]) == 0 {
+				return fmt.Errorf(
+					"must contain a client certificate and key at keys [%s, %s] for authType [mtls]; found keys [%s]",
+					corev1.TLSCertKey,
+					corev1.TLSPrivateKeyKey,
+					strings.Join(keys, ", "),
+				)
+			}
+		}
+	default:
+		return fmt.Errorf("has unsupported authType [%s]", authType)
+	}
+
+	return nil
+}
+
+// validateS3Credentials validates that a secret contains the access key id and secret access key for an
+// s3-compatible object storage.
+func validateS3Credentials(secret *corev1.Secret) error {
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf(
+				"must contain credentials at keys [AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY]; found keys [%s]",
+				strings.Join(secretKeys(secret), ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateCABundle validates that a secret contains a PEM encoded certificate authority bundle at a key.
+func validateCABundle(secret *corev1.Secret, key string) error {
+	if !x509.NewCertPool().AppendCertsFromPEM(secret.Data[key]) {
+		return fmt.Errorf(
+			"must contain a PEM encoded certificate authority bundle at key [%s]; found keys [%s]",
+			key,
+			strings.Join(secretKeys(secret), ", "),
+		)
+	}
+
+	return nil
+}
+
+// validateClientCert validates that a secret contains a matching PEM encoded client certificate and key.
+func validateClientCert(secret *corev1.Secret) error {
+	if _, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+		return fmt.Errorf(
+			"must contain a PEM encoded client certificate and key at keys [%s, %s]; %s",
+			corev1.TLSCertKey,
+			corev1.TLSPrivateKeyKey,
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+// secretKeys returns the sorted keys of a secret.
+func secretKeys(secret *corev1.Secret) []string {
+	keys := make([]string, 0, len(secret.Data))
+
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,369 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+)
+
+// testSecret returns a secret with the given data.
+func testSecret(data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+
+	return secret
+}
+
+// testCertificate returns a PEM encoded self-signed certificate and its PEM encoded key.
+func testCertificate(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestSecretValidators(t *testing.T) {
+	certPEM, keyPEM := testCertificate(t)
+	token := `{"access_token":"a","refresh_token":"b"}`
+
+	for _, tt := range []struct {
+		name     string
+		validate func(*corev1.Secret) error
+		data     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "ocm token for each cluster",
+			validate: func(s *corev1.Secret) error { return validateOCMTokens(s, []string{"b", "a"}) },
+			data:     map[string]string{"a": token, "b": token},
+		},
+		{
+			name:     "ocm token missing for a cluster",
+			validate: func(s *corev1.Secret) error { return validateOCMTokens(s, []string{"a", "b"}) },
+			data:     map[string]string{"a": token},
+			wantErr:  true,
+		},
+		{
+			name:     "ocm token for an unknown cluster",
+			validate: func(s *corev1.Secret) error { return validateOCMTokens(s, []string{"a"}) },
+			data:     map[string]string{"a": token, "c": token},
+			wantErr:  true,
+		},
+		{
+			name:     "ocm token which is not json",
+			validate: func(s *corev1.Secret) error { return validateOCMTokens(s, []string{"a"}) },
+			data:     map[string]string{"a": "token"},
+			wantErr:  true,
+		},
+		{
+			name:     "ocm service account",
+			validate: validateOCMServiceAccount,
+			data:     map[string]string{appsv1alpha1.OCMClientIDKey: "id", appsv1alpha1.OCMClientSecretKey: "secret"},
+		},
+		{
+			name:     "ocm service account without a client secret",
+			validate: validateOCMServiceAccount,
+			data:     map[string]string{appsv1alpha1.OCMClientIDKey: "id"},
+			wantErr:  true,
+		},
+		{
+			name:     "elasticsearch basic",
+			validate: elasticSearchAuth(appsv1alpha1.ElasticSearchAuthTypeBasic),
+			data:     map[string]string{"elastic": "password"},
+		},
+		{
+			name:     "elasticsearch basic with multiple users",
+			validate: elasticSearchAuth(appsv1alpha1.ElasticSearchAuthTypeBasic),
+			data:     map[string]string{"elastic": "password", "other": "password"},
+			wantErr:  true,
+		},
+		{
+			name:     "elasticsearch basic without a password",
+			validate: elasticSearchAuth(appsv1alpha1.ElasticSearchAuthTypeBasic),
+			data:     map[string]string{"elastic": ""},
+			wantErr:  true,
+		},
+		{
+			name:     "elasticsearch encoded api key",
+			validate: elasticSearchAuth(appsv1alpha1.ElasticSearchAuthTypeAPIKey),
+			data:     map[string]string{appsv1alpha1.ElasticSearchAPIKeyEncodedKey: "encoded"},
+		},
+		{
+			name:     "elasticsearch api key id and api key",
+			validate: elasticSearchAuth(appsv1alpha1.ElasticSearchAuthTypeAPIKey),
+			data:     map[string]string{appsv1alpha1.ElasticSearchAPIKeyIDKey: "id", appsv1alpha1.ElasticSearchAPIKeyKey: "key"},
+		},
+		{
+			name:     "elasticsearch encoded api key and api key id",
+			validate: elasticSearchAuth(appsv1alpha1.ElasticSearchAuthTypeAPIKey),
+			data: map[string]string{
+				appsv1alpha1.ElasticSearchAPIKeyEncodedKey: "encoded",
+				appsv1alpha1.ElasticSearchAPIKeyIDKey:      "id",
+				appsv1alpha1.ElasticSearchAPIKeyKey:        "key",
+			},
+			wantErr: true,
+		},
+		{
+			name:     "elasticsearch api key id without an api key",
+			validate: elasticSearchAuth(appsv1alpha1.ElasticSearchAuthTypeAPIKey),
+			data:     map[string]string{appsv1alpha1.ElasticSearchAPIKeyIDKey: "id"},
+			wantErr:  true,
+		},
+		{
+			name:     "elasticsearch unsupported auth type",
+			validate: elasticSearchAuth("token"),
+			data:     map[string]string{"elastic": "password"},
+			wantErr:  true,
+		},
+		{
+			name:     "splunk token",
+			validate: validateSplunkToken,
+			data:     map[string]string{"token": "hec"},
+		},
+		{
+			name:     "splunk without a token",
+			validate: validateSplunkToken,
+			data:     map[string]string{"hec": "hec"},
+			wantErr:  true,
+		},
+		{
+			name:     "loki basic",
+			validate: lokiAuth(appsv1alpha1.LokiAuthTypeBasic),
+			data:     map[string]string{"loki": "password"},
+		},
+		{
+			name:     "loki basic without a password",
+			validate: lokiAuth(appsv1alpha1.LokiAuthTypeBasic),
+			data:     map[string]string{"loki": ""},
+			wantErr:  true,
+		},
+		{
+			name:     "loki bearer",
+			validate: lokiAuth(appsv1alpha1.LokiAuthTypeBearer),
+			data:     map[string]string{"token": "bearer"},
+		},
+		{
+			name:     "loki bearer without a token",
+			validate: lokiAuth(appsv1alpha1.LokiAuthTypeBearer),
+			data:     map[string]string{"loki": "password"},
+			wantErr:  true,
+		},
+		{
+			name:     "kafka scram",
+			validate: kafkaAuth(appsv1alpha1.KafkaAuthTypeScramSHA512),
+			data:     map[string]string{"kafka": "password"},
+		},
+		{
+			name:     "kafka scram with multiple users",
+			validate: kafkaAuth(appsv1alpha1.KafkaAuthTypeScramSHA256),
+			data:     map[string]string{"kafka": "password", "other": "password"},
+			wantErr:  true,
+		},
+		{
+			name:     "kafka mtls",
+			validate: kafkaAuth(appsv1alpha1.KafkaAuthTypeMTLS),
+			data:     map[string]string{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		},
+		{
+			name:     "kafka mtls without a key",
+			validate: kafkaAuth(appsv1alpha1.KafkaAuthTypeMTLS),
+			data:     map[string]string{corev1.TLSCertKey: certPEM},
+			wantErr:  true,
+		},
+		{
+			name:     "s3 credentials",
+			validate: validateS3Credentials,
+			data:     map[string]string{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"},
+		},
+		{
+			name:     "s3 without a secret access key",
+			validate: validateS3Credentials,
+			data:     map[string]string{"AWS_ACCESS_KEY_ID": "id"},
+			wantErr:  true,
+		},
+		{
+			name:     "ca bundle",
+			validate: func(s *corev1.Secret) error { return validateCABundle(s, "ca.crt") },
+			data:     map[string]string{"ca.crt": certPEM},
+		},
+		{
+			name:     "ca bundle at another key",
+			validate: func(s *corev1.Secret) error { return validateCABundle(s, "ca.crt") },
+			data:     map[string]string{"tls.crt": certPEM},
+			wantErr:  true,
+		},
+		{
+			name:     "client certificate",
+			validate: validateClientCert,
+			data:     map[string]string{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		},
+		{
+			name:     "client certificate without a key",
+			validate: validateClientCert,
+			data:     map[string]string{corev1.TLSCertKey: certPEM},
+			wantErr:  true,
+		},
+	} {
+		err := tt.validate(testSecret(tt.data))
+		if tt.wantErr && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+	}
+}
+
+func TestSecretChecks(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		spec   appsv1alpha1.OCMLogForwarderSpec
+		fields []string
+	}{
+		{
+			name: "elasticsearch",
+			spec: appsv1alpha1.OCMLogForwarderSpec{
+				Ocm: appsv1alpha1.OCMLogForwarderSpecOcm{SecretRef: "ocm", ClusterId: "a"},
+				Backend: appsv1alpha1.OCMLogForwarderSpecBackend{
+					Type:          appsv1alpha1.BackendTypeElasticSearch,
+					ElasticSearch: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch{SecretRef: "es"},
+				},
+			},
+			fields: []string{".spec.ocm.secretRef", ".spec.backend.elasticSearch.secretRef"},
+		},
+		{
+			name: "referenced elasticsearch",
+			spec: appsv1alpha1.OCMLogForwarderSpec{
+				Ocm: appsv1alpha1.OCMLogForwarderSpecOcm{SecretRef: "ocm", ClusterId: "a"},
+				Backend: appsv1alpha1.OCMLogForwarderSpecBackend{
+					Type: appsv1alpha1.BackendTypeElasticSearch,
+					ElasticSearch: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearch{
+						SecretRef:        "es",
+						ElasticsearchRef: appsv1alpha1.OCMLogForwarderSpecBackendElasticSearchRef{Name: "es"},
+					},
+				},
+			},
+			fields: []string{".spec.ocm.secretRef"},
+		},
+		{
+			name: "loki without authentication",
+			spec: appsv1alpha1.OCMLogForwarderSpec{
+				Ocm: appsv1alpha1.OCMLogForwarderSpecOcm{
+					Clusters: []appsv1alpha1.OCMLogForwarderSpecOcmCluster{
+						{ClusterId: "a", SecretRef: "ocm"},
+						{ClusterId: "b", SecretRef: "other"},
+					},
+				},
+				Backend: appsv1alpha1.OCMLogForwarderSpecBackend{
+					Type: appsv1alpha1.BackendTypeLoki,
+					Loki: appsv1alpha1.OCMLogForwarderSpecBackendLoki{SecretRef: "loki", AuthType: appsv1alpha1.LokiAuthTypeNone},
+				},
+			},
+			fields: []string{".spec.ocm.clusters.secretRef", ".spec.ocm.clusters.secretRef"},
+		},
+		{
+			name: "named backends",
+			spec: appsv1alpha1.OCMLogForwarderSpec{
+				Ocm: appsv1alpha1.OCMLogForwarderSpecOcm{SecretRef: "ocm", ClusterId: "a"},
+				Backends: []appsv1alpha1.OCMLogForwarderSpecNamedBackend{
+					{Name: "splunk", OCMLogForwarderSpecBackend: appsv1alpha1.OCMLogForwarderSpecBackend{
+						Type:   appsv1alpha1.BackendTypeSplunk,
+						Splunk: appsv1alpha1.OCMLogForwarderSpecBackendSplunk{SecretRef: "splunk"},
+					}},
+					{Name: "kafka", OCMLogForwarderSpecBackend: appsv1alpha1.OCMLogForwarderSpecBackend{
+						Type:  appsv1alpha1.BackendTypeKafka,
+						Kafka: appsv1alpha1.OCMLogForwarderSpecBackendKafka{SecretRef: "kafka", AuthType: appsv1alpha1.KafkaAuthTypeMTLS},
+					}},
+				},
+			},
+			fields: []string{".spec.ocm.secretRef", ".spec.backends[splunk].splunk.secretRef", ".spec.backends[kafka].kafka.secretRef"},
+		},
+	} {
+		checks := secretChecks(&appsv1alpha1.OCMLogForwarder{Spec: tt.spec})
+
+		fields := make([]string, len(checks))
+		for i := range checks {
+			fields[i] = checks[i].field
+		}
+
+		if len(fields) != len(tt.fields) {
+			t.Errorf("%s: expected checks for fields %v; found %v", tt.name, tt.fields, fields)
+
+			continue
+		}
+
+		for i := range fields {
+			if fields[i] != tt.fields[i] {
+				t.Errorf("%s: expected checks for fields %v; found %v", tt.name, tt.fields, fields)
+
+				break
+			}
+		}
+	}
+}
+
+func elasticSearchAuth(authType string) func(*corev1.Secret) error {
+	return func(secret *corev1.Secret) error { return validateElasticSearchAuth(secret, authType) }
+}
+
+func lokiAuth(authType string) func(*corev1.Secret) error {
+	return func(secret *corev1.Secret) error { return validateLokiAuth(secret, authType) }
+}
+
+func kafkaAuth(authType string) func(*corev1.Secret) error {
+	return func(secret *corev1.Secret) error { return validateKafkaAuth(secret, authType) }
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suspend
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1/ocmlogforwarder"
+)
+
+// OCMLogForwarderSuspend performs the logic to suspend or resume forwarding for a OCMLogForwarder object, prior
+// to creating the forwarder, so that the forwarder deployments are rendered with the replica count for the
+// requested state.  When forwarding is suspended, the replica count of each existing forwarder deployment is
+// recorded in the status of the object.  When forwarding is resumed, each forwarder deployment is scaled back to
+// its recorded replica count, and the recorded replica counts are cleared.
+func OCMLogForwarderSuspend(r workload.Reconciler, req *workload.Request) (bool, error) {
+	component, err := ocmlogforwarder.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case component.Spec.Suspend && !component.GetSuspendedStatus():
+		replicas, err := deploymentReplicas(r, req, component)
+		if err != nil {
+			return false, err
+		}
+
+		req.Log.Info("suspending forwarding", "replicas", replicas)
+
+		component.SetSuspendedReplicasStatus(replicas)
+		component.SetSuspendedStatus(true)
+	case !component.Spec.Suspend && component.GetSuspendedStatus():
+		req.Log.Info("resuming forwarding", "replicas", component.GetSuspendedReplicasStatus())
+
+		if err := resumeDeployments(r, req, component); err != nil {
+			return false, err
+		}
+
+		component.SetSuspendedReplicasStatus(nil)
+		component.SetSuspendedStatus(false)
+	}
+
+	return true, nil
+}
+
+// deploymentReplicas returns the replica count of each existing forwarder deployment of a component, by
+// deployment name.  Deployments which are already scaled to zero are omitted, so that they are restored
+// with the default replica count.
+func deploymentReplicas(
+	r workload.Reconciler,
+	req *workload.Request,
+	component *appsv1alpha1.OCMLogForwarder,
+) (map[string]int32, error) {
+	var replicas map[string]int32
+
+	for _, cluster := range component.GetClusters() {
+		deployment, err := getDeployment(r, req, component, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		if deployment == nil || deployment.Spec.Replicas == nil || *deployment.Spec.Replicas == 0 {
+			continue
+		}
+
+		if replicas == nil {
+			replicas = map[string]int32{}
+		}
+
+		replicas[deployment.Name] = *deployment.Spec.Replicas
+	}
+
+	return replicas, nil
+}
+
+// resumeDeployments scales each existing forwarder deployment of a component, which is scaled to zero, back to
+// the replica count which it had when forwarding was suspended.  The replica count of the forwarder deployments
+// is not managed while forwarding is not suspended, so they are scaled here rather than when they are rendered.
+func resumeDeployments(
+	r workload.Reconciler,
+	req *workload.Request,
+	component *appsv1alpha1.OCMLogForwarder,
+) error {
+	for _, cluster := range component.GetClusters() {
+		deployment, err := getDeployment(r, req, component, cluster)
+		if err != nil {
+			return err
+		}
+
+		if deployment == nil || (deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 0) {
+			continue
+		}
+
+		original := deployment.DeepCopy()
+		replicas := component.GetResumedReplicas(deployment.Name)
+		deployment.Spec.Replicas = &replicas
+
+		if err := r.Patch(
+			req.Context,
+			deployment,
+			client.MergeFrom(original),
+			&client.PatchOptions{FieldManager: r.GetFieldManager()},
+		); err != nil {
+			return fmt.Errorf("unable to scale deployment [%s/%s], %w", deployment.Namespace, deployment.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getDeployment returns the existing forwarder deployment of a component for a cluster, or nil when the
+// deployment does not exist.
+func getDeployment(
+	r workload.Reconciler,
+	req *workload.Request,
+	component *appsv1alpha1.OCMLogForwarder,
+	cluster appsv1alpha1.OCMLogForwarderSpecOcmCluster,
+) (*appsv1.Deployment, error) {
+	name := ocmlogforwarder.DeploymentName(component, cluster)
+	deployment := &appsv1.Deployment{}
+
+	if err := r.Get(req.Context, client.ObjectKey{Namespace: component.Namespace, Name: name}, deployment); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to retrieve deployment [%s/%s], %w", component.Namespace, name, err)
+	}
+
+	return deployment, nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suspend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	"github.com/scottd018/ocm-log-forwarder-operator/internal/fakereconciler"
+)
+
+func TestOCMLogForwarderSuspend(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		suspend       bool
+		suspended     bool
+		recorded      map[string]int32
+		clusters      []string
+		deployments   map[string]int32
+		wantSuspended bool
+		wantRecorded  map[string]int32
+		wantReplicas  map[string]int32
+	}{
+		{
+			name:         "not suspended",
+			deployments:  map[string]int32{"forwarder": 2},
+			wantReplicas: map[string]int32{"forwarder": 2},
+		},
+		{
+			name:          "suspend records the replicas",
+			suspend:       true,
+			deployments:   map[string]int32{"forwarder": 3},
+			wantSuspended: true,
+			wantRecorded:  map[string]int32{"forwarder": 3},
+			wantReplicas:  map[string]int32{"forwarder": 3},
+		},
+		{
+			name:          "suspend records the replicas of each cluster",
+			suspend:       true,
+			clusters:      []string{"abc123", "def456"},
+			deployments:   map[string]int32{"forwarder-abc123": 2, "forwarder-def456": 0},
+			wantSuspended: true,
+			wantRecorded:  map[string]int32{"forwarder-abc123": 2},
+			wantReplicas:  map[string]int32{"forwarder-abc123": 2, "forwarder-def456": 0},
+		},
+		{
+			name:          "suspend without a deployment",
+			suspend:       true,
+			wantSuspended: true,
+		},
+		{
+			name:          "suspended",
+			suspend:       true,
+			suspended:     true,
+			recorded:      map[string]int32{"forwarder": 3},
+			deployments:   map[string]int32{"forwarder": 0},
+			wantSuspended: true,
+			wantRecorded:  map[string]int32{"forwarder": 3},
+			wantReplicas:  map[string]int32{"forwarder": 0},
+		},
+		{
+			name:         "resume restores the recorded replicas",
+			suspended:    true,
+			recorded:     map[string]int32{"forwarder": 3},
+			deployments:  map[string]int32{"forwarder": 0},
+			wantReplicas: map[string]int32{"forwarder": 3},
+		},
+		{
+			name:         "resume restores the default replicas",
+			suspended:    true,
+			clusters:     []string{"abc123", "def456"},
+			recorded:     map[string]int32{"forwarder-abc123": 2},
+			deployments:  map[string]int32{"forwarder-abc123": 0, "forwarder-def456": 0},
+			wantReplicas: map[string]int32{"forwarder-abc123": 2, "forwarder-def456": appsv1alpha1.DefaultReplicas},
+		},
+		{
+			name:         "resume leaves a scaled deployment",
+			suspended:    true,
+			recorded:     map[string]int32{"forwarder": 3},
+			deployments:  map[string]int32{"forwarder": 2},
+			wantReplicas: map[string]int32{"forwarder": 2},
+		},
+		{
+			name:      "resume without a deployment",
+			suspended: true,
+			recorded:  map[string]int32{"forwarder": 3},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			component := &appsv1alpha1.OCMLogForwarder{
+				ObjectMeta: metav1.ObjectMeta{Name: "forwarder", Namespace: "ocm"},
+				Spec: appsv1alpha1.OCMLogForwarderSpec{
+					Ocm:     appsv1alpha1.OCMLogForwarderSpecOcm{ClusterId: "abc123"},
+					Suspend: tt.suspend,
+				},
+				Status: appsv1alpha1.OCMLogForwarderStatus{Suspended: tt.suspended, SuspendedReplicas: tt.recorded},
+			}
+
+			for _, clusterID := range tt.clusters {
+				component.Spec.Ocm.Clusters = append(component.Spec.Ocm.Clusters, appsv1alpha1.OCMLogForwarderSpecOcmCluster{ClusterId: clusterID})
+			}
+
+			objects := []client.Object{}
+
+			for name, replicas := range tt.deployments {
+				replicas := replicas
+
+				objects = append(objects, &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ocm"},
+					Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				})
+			}
+
+			reconciler := fakereconciler.New(objects...)
+
+			proceed, err := OCMLogForwarderSuspend(reconciler, fakereconciler.NewRequest(component))
+			if err != nil || !proceed {
+				t.Fatalf("expected phase to proceed; found proceed [%t] with error [%v]", proceed, err)
+			}
+
+			if component.GetSuspendedStatus() != tt.wantSuspended {
+				t.Errorf("expected suspended [%t]; found [%t]", tt.wantSuspended, component.GetSuspendedStatus())
+			}
+
+			if !reflect.DeepEqual(component.GetSuspendedReplicasStatus(), tt.wantRecorded) {
+				t.Errorf("expected recorded replicas %v; found %v", tt.wantRecorded, component.GetSuspendedReplicasStatus())
+			}
+
+			for name, want := range tt.wantReplicas {
+				deployment := &appsv1.Deployment{}
+				if err := reconciler.Get(context.TODO(), client.ObjectKey{Namespace: "ocm", Name: name}, deployment); err != nil {
+					t.Fatalf("unable to retrieve deployment [%s]: %v", name, err)
+				}
+
+				if *deployment.Spec.Replicas != want {
+					t.Errorf("expected deployment [%s] to have [%d] replicas; found [%d]", name, want, *deployment.Spec.Replicas)
+				}
+			}
+
+			component.SetPhaseCondition(&status.PhaseCondition{Phase: "Suspend", State: status.PhaseStateComplete})
+
+			wantCondition := metav1.ConditionFalse
+			if tt.wantSuspended {
+				wantCondition = metav1.ConditionTrue
+			}
+
+			if len(component.Status.Conditions) != 1 {
+				t.Fatalf("expected a single condition; found %d", len(component.Status.Conditions))
+			}
+
+			condition := component.Status.Conditions[0]
+
+			if condition.Type != appsv1alpha1.ConditionTypeSuspended || condition.Status != wantCondition {
+				t.Errorf("expected condition [%s=%s]; found [%s=%s]",
+					appsv1alpha1.ConditionTypeSuspended, wantCondition, condition.Type, condition.Status)
+			}
+		})
+	}
+}
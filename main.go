@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	appsv1alpha1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/scottd018/ocm-log-forwarder-operator/apis/apps/v1beta1"
 	appscontrollers "github.com/scottd018/ocm-log-forwarder-operator/controllers/apps"
 	//+kubebuilder:scaffold:imports
 )
@@ -51,6 +52,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -97,6 +99,7 @@ func main() {
 
 	reconcilers := []ReconcilerInitializer{
 		appscontrollers.NewOCMLogForwarderReconciler(mgr),
+		appscontrollers.NewClusterOCMLogForwarderReconciler(mgr),
 		//+kubebuilder:scaffold:reconcilers
 	}
 
@@ -107,6 +110,21 @@ func main() {
 		}
 	}
 
+	// webhooks may be disabled when running the manager locally, where the webhook server certificates do
+	// not exist.
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&appsv1beta1.OCMLogForwarder{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OCMLogForwarder")
+			os.Exit(1)
+		}
+
+		if err = (&appsv1alpha1.OCMLogForwarder{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OCMLogForwarder")
+			os.Exit(1)
+		}
+	}
+	//+kubebuilder:scaffold:builder
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)